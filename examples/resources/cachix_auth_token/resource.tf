@@ -0,0 +1,12 @@
+# Create a token for CI that expires at the end of the year
+resource "cachix_auth_token" "ci" {
+  description = "GitHub Actions"
+  expires_at  = "2026-12-31T23:59:59Z"
+}
+
+# Pass the token to CI, e.g. as a GitHub Actions secret
+resource "github_actions_secret" "cachix" {
+  repository      = "my-project"
+  secret_name     = "CACHIX_AUTH_TOKEN"
+  plaintext_value = cachix_auth_token.ci.token
+}
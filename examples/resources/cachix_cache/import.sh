@@ -1 +1,8 @@
+# Import using the cache name
 terraform import cachix_cache.example my-cache-name
+
+# Import using the cache URI
+terraform import cachix_cache.example https://my-cache-name.cachix.org
+
+# Import using the owner and cache name, checking the cache belongs to that account
+terraform import cachix_cache.example my-github-user/my-cache-name
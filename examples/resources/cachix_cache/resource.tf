@@ -1,13 +1,36 @@
 # Create a public binary cache
 resource "cachix_cache" "my_project" {
-  name      = "my-project"
-  is_public = true
+  name        = "my-project"
+  is_public   = true
+  priority    = 30 # preferred over cache.nixos.org (40)
+  compression = "zstd"
 }
 
-# Create a private binary cache
+# Create a private binary cache that cannot be destroyed by Terraform
 resource "cachix_cache" "private_cache" {
-  name      = "my-private-cache"
-  is_public = false
+  name            = "my-private-cache"
+  is_public       = false
+  deletion_policy = "protect"
+}
+
+# Create a cache whose store paths are signed with your own key
+resource "cachix_cache" "self_signed" {
+  name             = "my-self-signed-cache"
+  signing_key_mode = "self"
+}
+
+# Keep a preview cache small by garbage collecting old store paths
+resource "cachix_cache" "previews" {
+  name                = "my-project-previews"
+  retention_days      = 14
+  max_storage_size_gb = 5
+  force_destroy       = true # previews can be rebuilt, allow destroying while non-empty
+}
+
+# Take over a cache that was created outside Terraform
+resource "cachix_cache" "existing" {
+  name           = "my-existing-cache"
+  adopt_existing = true
 }
 
 # Output for nix.conf configuration
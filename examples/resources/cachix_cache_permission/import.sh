@@ -0,0 +1,5 @@
+# Import a user's access using the cache name and GitHub username
+terraform import cachix_cache_permission.alice my-private-cache/alice
+
+# Import a team's access using the cache name and GitHub org/team-slug
+terraform import cachix_cache_permission.developers my-private-cache/my-org/developers
@@ -0,0 +1,18 @@
+resource "cachix_cache" "private" {
+  name      = "my-private-cache"
+  is_public = false
+}
+
+# Push access for a maintainer
+resource "cachix_cache_permission" "alice" {
+  cache      = cachix_cache.private.name
+  username   = "alice"
+  permission = "write"
+}
+
+# Pull access for a whole GitHub team
+resource "cachix_cache_permission" "developers" {
+  cache      = cachix_cache.private.name
+  team       = "my-org/developers"
+  permission = "read"
+}
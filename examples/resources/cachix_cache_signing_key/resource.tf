@@ -0,0 +1,25 @@
+resource "cachix_cache" "self_signed" {
+  name             = "my-self-signed-cache"
+  signing_key_mode = "self"
+}
+
+# Register a key generated with:
+#   nix-store --generate-binary-cache-key my-self-signed-cache-1 secret.key public.key
+resource "cachix_cache_signing_key" "current" {
+  cache      = cachix_cache.self_signed.name
+  public_key = trimspace(file("${path.module}/public.key"))
+
+  # Optional: checked against public_key, never stored in state
+  secret_key = var.signing_secret_key
+
+  lifecycle {
+    # Register the new key before removing the old one when rotating
+    create_before_destroy = true
+  }
+}
+
+variable "signing_secret_key" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
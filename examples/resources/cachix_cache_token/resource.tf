@@ -0,0 +1,23 @@
+resource "cachix_cache" "private" {
+  name      = "my-private-cache"
+  is_public = false
+}
+
+# Read access for machines pulling from the private cache
+resource "cachix_cache_token" "consumers" {
+  cache      = cachix_cache.private.name
+  permission = "read"
+}
+
+# Push access for CI, rotated yearly
+resource "cachix_cache_token" "ci" {
+  cache      = cachix_cache.private.name
+  permission = "write"
+  expires_at = "2026-12-31T23:59:59Z"
+}
+
+# netrc file for the machines, referenced by netrc-file in nix.conf
+resource "local_sensitive_file" "netrc" {
+  filename = "${path.module}/netrc"
+  content  = "${cachix_cache_token.consumers.netrc_line}\n"
+}
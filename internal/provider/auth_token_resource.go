@@ -0,0 +1,241 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &AuthTokenResource{}
+	_ resource.ResourceWithConfigure = &AuthTokenResource{}
+)
+
+// NewAuthTokenResource creates a new auth token resource instance.
+func NewAuthTokenResource() resource.Resource {
+	return &AuthTokenResource{}
+}
+
+// AuthTokenResource defines the resource implementation.
+type AuthTokenResource struct {
+	client *CachixClient
+}
+
+// AuthTokenResourceModel describes the resource data model.
+type AuthTokenResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Token       types.String `tfsdk:"token"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *AuthTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_token"
+}
+
+// Schema defines the schema for the resource.
+func (r *AuthTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manages a Cachix personal auth token.",
+		MarkdownDescription: "Manages a Cachix personal auth token of the authenticated user, e.g. for CI. The secret is only returned when the token is created. Destroying the resource revokes the token.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "A description of what the token is used for. Changing this forces a new token to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "When the token expires, as an RFC 3339 timestamp such as `2030-01-01T00:00:00Z`. The token does not expire when unset. Changing this forces a new token to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the token, e.g. for `CACHIX_AUTH_TOKEN`. Only known after the token is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token was created, as an RFC 3339 timestamp.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AuthTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = getClientFromProviderData(req.ProviderData, &resp.Diagnostics, "Resource")
+}
+
+// Create creates a new auth token.
+func (r *AuthTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating auth token", map[string]any{
+		"description": data.Description.ValueString(),
+		"expires_at":  data.ExpiresAt.ValueString(),
+	})
+
+	token, err := r.client.CreateAuthToken(ctx, CreateAuthTokenRequest{
+		Description: data.Description.ValueString(),
+		ExpiresAt:   data.ExpiresAt.ValueString(),
+	})
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "auth token",
+		ResourceName: data.Description.ValueString(),
+		Operation:    "create",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	data.ID = types.StringValue(token.ID)
+	data.Token = types.StringValue(token.Secret)
+	data.CreatedAt = rfc3339Value(token.CreatedAt, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Created auth token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the auth token from the API, removing it from state if it
+// was revoked outside Terraform.
+func (r *AuthTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading auth token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	token, err := r.client.GetAuthToken(ctx, data.ID.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "auth token",
+		ResourceName: data.Description.ValueString(),
+		Operation:    "read",
+	}
+	if errorHandler.HandleTokenRead(ctx, err, token != nil && token.Revoked, &resp.State, map[string]any{
+		"id": data.ID.ValueString(),
+	}) {
+		return
+	}
+
+	// Only the description and creation time are refreshed. The API never
+	// returns the secret again, and expires_at stays as configured since
+	// the API may report it in a different format.
+	data.Description = types.StringValue(token.Description)
+	if token.CreatedAt != "" {
+		data.CreatedAt = rfc3339Value(token.CreatedAt, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only copies the plan into state, as a changed description or expiry
+// replaces the token instead.
+func (r *AuthTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete revokes the auth token.
+func (r *AuthTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking auth token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	err := r.client.RevokeAuthToken(ctx, data.ID.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "auth token",
+		ResourceName: data.Description.ValueString(),
+		Operation:    "revoke",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Auth token already revoked", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+		}
+		return
+	}
+
+	tflog.Trace(ctx, "Revoked auth token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAuthTokenResource_Schema_TokenSensitive(t *testing.T) {
+	_, s := newTestResource(t, NewAuthTokenResource, "")
+
+	attr, ok := s.Attributes["token"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected token to be a string attribute")
+	}
+	if !attr.Sensitive {
+		t.Error("expected token to be sensitive")
+	}
+}
+
+func TestAuthTokenResource_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/token" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var reqBody CreateAuthTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(AuthToken{
+			ID:          "token-1",
+			Description: reqBody.Description,
+			ExpiresAt:   "2030-01-01T00:00:00.000Z",
+			CreatedAt:   "2025-01-01T00:00:00Z",
+			Secret:      "secret-value",
+		})
+	}))
+	defer server.Close()
+
+	r, s := newTestResource(t, NewAuthTokenResource, server.URL)
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"description": tftypes.NewValue(tftypes.String, "ci"),
+		"expires_at":  tftypes.NewValue(tftypes.String, "2030-01-01T00:00:00Z"),
+		"token":       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"created_at":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var result AuthTokenResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.ID.ValueString() != "token-1" {
+		t.Errorf("expected id 'token-1', got %q", result.ID.ValueString())
+	}
+	if result.Token.ValueString() != "secret-value" {
+		t.Errorf("expected token 'secret-value', got %q", result.Token.ValueString())
+	}
+	if result.ExpiresAt.ValueString() != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected configured expires_at to be kept, got %q", result.ExpiresAt.ValueString())
+	}
+}
+
+func TestAuthTokenResource_Read(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		token         AuthToken
+		expectRemoved bool
+	}{
+		{
+			name:   "active",
+			status: http.StatusOK,
+			token: AuthToken{
+				ID:          "token-1",
+				Description: "ci",
+				ExpiresAt:   "2030-01-01T00:00:00.000Z",
+				CreatedAt:   "2025-01-01T01:00:00+01:00",
+			},
+		},
+		{
+			name:          "revoked",
+			status:        http.StatusOK,
+			token:         AuthToken{ID: "token-1", Description: "ci", Revoked: true},
+			expectRemoved: true,
+		},
+		{
+			name:          "deleted",
+			status:        http.StatusNotFound,
+			expectRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/token/token-1" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(tt.token)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestResource(t, NewAuthTokenResource, server.URL)
+			state := tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "token-1"),
+				"description": tftypes.NewValue(tftypes.String, "ci"),
+				"expires_at":  tftypes.NewValue(tftypes.String, "2030-01-01T00:00:00Z"),
+				"token":       tftypes.NewValue(tftypes.String, "secret-value"),
+			})}
+			resp := &resource.ReadResponse{State: state}
+
+			r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tt.expectRemoved {
+				t.Fatalf("expected removed=%v, got state %v", tt.expectRemoved, resp.State.Raw)
+			}
+			if tt.expectRemoved {
+				return
+			}
+
+			var result AuthTokenResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Token.ValueString() != "secret-value" {
+				t.Errorf("expected token to be kept in state, got %q", result.Token.ValueString())
+			}
+			if result.ExpiresAt.ValueString() != "2030-01-01T00:00:00Z" {
+				t.Errorf("expected configured expires_at to be kept, got %q", result.ExpiresAt.ValueString())
+			}
+			if result.CreatedAt.ValueString() != "2025-01-01T00:00:00Z" {
+				t.Errorf("expected created_at '2025-01-01T00:00:00Z', got %q", result.CreatedAt.ValueString())
+			}
+		})
+	}
+}
@@ -32,8 +32,57 @@ type CacheDataSourceModel struct {
 	URI               types.String `tfsdk:"uri"`
 	IsPublic          types.Bool   `tfsdk:"is_public"`
 	PublicSigningKeys types.List   `tfsdk:"public_signing_keys"`
+	Priority          types.Int64  `tfsdk:"priority"`
+	RetentionDays     types.Int64  `tfsdk:"retention_days"`
+	MaxStorageSizeGB  types.Int64  `tfsdk:"max_storage_size_gb"`
+	Compression       types.String `tfsdk:"compression"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	Owner             types.String `tfsdk:"owner"`
+	StorageBytes      types.Int64  `tfsdk:"storage_bytes"`
+	StorePathCount    types.Int64  `tfsdk:"store_path_count"`
 }
 
+var _ CacheModel = &CacheDataSourceModel{}
+
+// SetID sets the id attribute.
+func (m *CacheDataSourceModel) SetID(v types.String) { m.ID = v }
+
+// SetName sets the name attribute.
+func (m *CacheDataSourceModel) SetName(v types.String) { m.Name = v }
+
+// SetIsPublic sets the is_public attribute.
+func (m *CacheDataSourceModel) SetIsPublic(v types.Bool) { m.IsPublic = v }
+
+// SetURI sets the uri attribute.
+func (m *CacheDataSourceModel) SetURI(v types.String) { m.URI = v }
+
+// SetPublicSigningKeys sets the public_signing_keys attribute.
+func (m *CacheDataSourceModel) SetPublicSigningKeys(v types.List) { m.PublicSigningKeys = v }
+
+// SetPriority sets the priority attribute.
+func (m *CacheDataSourceModel) SetPriority(v types.Int64) { m.Priority = v }
+
+// SetRetentionDays sets the retention_days attribute.
+func (m *CacheDataSourceModel) SetRetentionDays(v types.Int64) { m.RetentionDays = v }
+
+// SetMaxStorageSizeGB sets the max_storage_size_gb attribute.
+func (m *CacheDataSourceModel) SetMaxStorageSizeGB(v types.Int64) { m.MaxStorageSizeGB = v }
+
+// SetCompression sets the compression attribute.
+func (m *CacheDataSourceModel) SetCompression(v types.String) { m.Compression = v }
+
+// SetCreatedAt sets the created_at attribute.
+func (m *CacheDataSourceModel) SetCreatedAt(v types.String) { m.CreatedAt = v }
+
+// SetOwner sets the owner attribute.
+func (m *CacheDataSourceModel) SetOwner(v types.String) { m.Owner = v }
+
+// SetStorageBytes sets the storage_bytes attribute.
+func (m *CacheDataSourceModel) SetStorageBytes(v types.Int64) { m.StorageBytes = v }
+
+// SetStorePathCount sets the store_path_count attribute.
+func (m *CacheDataSourceModel) SetStorePathCount(v types.Int64) { m.StorePathCount = v }
+
 // Metadata returns the data source type name.
 func (d *CacheDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cache"
@@ -68,6 +117,38 @@ func (d *CacheDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The substituter priority advertised in the cache's `nix-cache-info`. Lower values are preferred.",
+				Computed:            true,
+			},
+			"retention_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days store paths are kept before being garbage collected. `0` means the plan default, and it is null when the Cachix API does not report it.",
+				Computed:            true,
+			},
+			"max_storage_size_gb": schema.Int64Attribute{
+				MarkdownDescription: "Maximum storage size of the cache in GB before the oldest store paths are garbage collected. `0` means the plan default, and it is null when the Cachix API does not report it.",
+				Computed:            true,
+			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "The compression method used for NARs pushed to the cache, `xz` or `zstd`.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the cache was created, as an RFC 3339 timestamp.",
+				Computed:            true,
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "The account that owns the cache.",
+				Computed:            true,
+			},
+			"storage_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The storage used by the cache in bytes.",
+				Computed:            true,
+			},
+			"store_path_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of store paths in the cache.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -109,7 +190,7 @@ func (d *CacheDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		"is_public":  cache.IsPublic,
 	})
 
-	data.ID, data.Name, data.IsPublic, data.URI, data.PublicSigningKeys = mapCacheToState(ctx, cache, &resp.Diagnostics)
+	mapCacheToState(ctx, cache, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
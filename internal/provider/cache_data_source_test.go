@@ -36,12 +36,21 @@ func TestCacheDataSource_Schema(t *testing.T) {
 
 	d.Schema(context.Background(), req, resp)
 
-	attrs := []string{"id", "name", "uri", "is_public", "public_signing_keys"}
+	attrs := []string{
+		"id", "name", "uri", "is_public", "public_signing_keys", "priority", "retention_days", "max_storage_size_gb", "compression",
+		"created_at", "owner", "storage_bytes", "store_path_count",
+	}
 	for _, attr := range attrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("expected '%s' attribute in schema", attr)
 		}
 	}
+
+	for _, attr := range []string{"created_at", "owner", "storage_bytes", "store_path_count"} {
+		if a, ok := resp.Schema.Attributes[attr]; ok && (!a.IsComputed() || a.IsOptional() || a.IsRequired()) {
+			t.Errorf("expected '%s' to be read-only", attr)
+		}
+	}
 }
 
 // Acceptance Tests
@@ -109,8 +118,9 @@ data "cachix_cache" "test" {
 func testAccCacheDataSourceWithResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "cachix_cache" "test" {
-  name      = %[1]q
-  is_public = true
+  name          = %[1]q
+  is_public     = true
+  force_destroy = true
 }
 
 data "cachix_cache" "test" {
@@ -0,0 +1,342 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &CachePermissionResource{}
+	_ resource.ResourceWithConfigure   = &CachePermissionResource{}
+	_ resource.ResourceWithImportState = &CachePermissionResource{}
+)
+
+// cachePermissions lists the access levels that can be granted on a cache.
+var cachePermissions = []string{"read", "write", "admin"}
+
+// githubUsernameValidator validates GitHub user and organization names.
+var githubUsernameValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`),
+	"must be a GitHub username: letters, numbers and hyphens, not starting or ending with a hyphen",
+)
+
+// githubTeamValidator validates GitHub teams given as org/team-slug.
+var githubTeamValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?/[A-Za-z0-9_.-]+$`),
+	"must be a GitHub team in the form org/team-slug",
+)
+
+// NewCachePermissionResource creates a new cache permission resource instance.
+func NewCachePermissionResource() resource.Resource {
+	return &CachePermissionResource{}
+}
+
+// CachePermissionResource defines the resource implementation.
+type CachePermissionResource struct {
+	client *CachixClient
+}
+
+// CachePermissionResourceModel describes the resource data model.
+type CachePermissionResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Cache      types.String `tfsdk:"cache"`
+	Username   types.String `tfsdk:"username"`
+	Team       types.String `tfsdk:"team"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+// apiPermission returns the permission as sent to the API.
+func (m CachePermissionResourceModel) apiPermission() CachePermission {
+	return CachePermission{
+		Username:   m.Username.ValueString(),
+		Team:       m.Team.ValueString(),
+		Permission: m.Permission.ValueString(),
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *CachePermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_permission"
+}
+
+// Schema defines the schema for the resource.
+func (r *CachePermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Grants a GitHub user or team access to a Cachix binary cache.",
+		MarkdownDescription: "Grants a GitHub user or team access to a Cachix binary cache. Destroying the resource revokes the access.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the permission, in the form `<cache>/<username>` or `<cache>/<org>/<team>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the cache to grant access to. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: CacheNameValidators(),
+			},
+			"username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The GitHub user to grant access to. Exactly one of `username` and `team` must be set. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					githubUsernameValidator,
+					stringvalidator.ExactlyOneOf(path.MatchRoot("team")),
+				},
+			},
+			"team": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The GitHub team to grant access to, in the form `org/team-slug`. Exactly one of `username` and `team` must be set. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					githubTeamValidator,
+				},
+			},
+			"permission": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The access to grant: `read` to pull from the cache, `write` to also push to it, or `admin` to also manage it. Can be changed in place.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(cachePermissions...),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CachePermissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = getClientFromProviderData(req.ProviderData, &resp.Diagnostics, "Resource")
+}
+
+// Create grants the permission.
+func (r *CachePermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CachePermissionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	permission := data.apiPermission()
+
+	tflog.Debug(ctx, "Granting cache permission", map[string]any{
+		"cache":      data.Cache.ValueString(),
+		"subject":    permission.Subject(),
+		"permission": permission.Permission,
+	})
+
+	err := r.client.SetCachePermission(ctx, data.Cache.ValueString(), permission)
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache permission",
+		ResourceName: permission.Subject(),
+		Operation:    "create",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	data.ID = types.StringValue(data.Cache.ValueString() + "/" + permission.Subject())
+
+	tflog.Trace(ctx, "Granted cache permission", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the Terraform state with the latest data from the API.
+func (r *CachePermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CachePermissionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading cache permission", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	permissions, err := r.client.ListCachePermissions(ctx, data.Cache.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache",
+		ResourceName: data.Cache.ValueString(),
+		Operation:    "read",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Cache not found, removing permission from state", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	current, ok := findCachePermission(permissions, data.apiPermission())
+	if !ok {
+		tflog.Warn(ctx, "Cache permission revoked outside Terraform, removing from state", map[string]any{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Permission = types.StringValue(current.Permission)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update changes the granted permission in place.
+func (r *CachePermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CachePermissionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	permission := data.apiPermission()
+
+	tflog.Debug(ctx, "Updating cache permission", map[string]any{
+		"id":         data.ID.ValueString(),
+		"permission": permission.Permission,
+	})
+
+	err := r.client.SetCachePermission(ctx, data.Cache.ValueString(), permission)
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache permission",
+		ResourceName: permission.Subject(),
+		Operation:    "update",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated cache permission", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete revokes the permission.
+func (r *CachePermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CachePermissionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	permission := data.apiPermission()
+
+	tflog.Debug(ctx, "Revoking cache permission", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	err := r.client.RemoveCachePermission(ctx, data.Cache.ValueString(), permission)
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache permission",
+		ResourceName: permission.Subject(),
+		Operation:    "revoke",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Cache permission already revoked", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+		}
+		return
+	}
+
+	tflog.Trace(ctx, "Revoked cache permission", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
+
+// ImportState imports an existing permission using an ID of the form
+// cache/username or cache/org/team.
+func (r *CachePermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Importing cache permission", map[string]any{
+		"id": req.ID,
+	})
+
+	cacheName, subject, ok := strings.Cut(req.ID, "/")
+	if !ok || cacheName == "" || subject == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form \"cache/username\" or \"cache/org/team\", got %q.", req.ID),
+		)
+		return
+	}
+
+	attribute := "username"
+	if strings.Contains(subject, "/") {
+		attribute = "team"
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cache"), cacheName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), subject)...)
+}
+
+// findCachePermission returns the permission granted to the same GitHub user
+// or team as want. GitHub names are case-insensitive.
+func findCachePermission(permissions []CachePermission, want CachePermission) (CachePermission, bool) {
+	for _, p := range permissions {
+		if want.Team != "" && strings.EqualFold(p.Team, want.Team) {
+			return p, true
+		}
+		if want.Username != "" && strings.EqualFold(p.Username, want.Username) {
+			return p, true
+		}
+	}
+	return CachePermission{}, false
+}
@@ -0,0 +1,220 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testPermissionServer is an in-memory Cachix API holding the permissions of
+// the cache "my-cache".
+type testPermissionServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	permissions map[string]string // GitHub user or team to permission
+	requests    []string
+}
+
+// newTestPermissionServer starts a fake Cachix API with the given
+// permissions. Teams are keyed as org/team.
+func newTestPermissionServer(t *testing.T, permissions map[string]string) *testPermissionServer {
+	t.Helper()
+
+	s := &testPermissionServer{permissions: permissions}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		const prefix = "/cache/my-cache/permissions"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == prefix:
+			list := []CachePermission{}
+			for subject, permission := range s.permissions {
+				p := CachePermission{Username: subject, Permission: permission}
+				if strings.Contains(subject, "/") {
+					p = CachePermission{Team: subject, Permission: permission}
+				}
+				list = append(list, p)
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPut && r.URL.Path == prefix+"/user/alice":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			s.permissions["alice"] = body["permission"]
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == prefix+"/user/alice":
+			if _, ok := s.permissions["alice"]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(s.permissions, "alice")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// testAlicePermission is the state of alice's write permission on my-cache.
+var testAlicePermission = map[string]tftypes.Value{
+	"id":         tftypes.NewValue(tftypes.String, "my-cache/alice"),
+	"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+	"username":   tftypes.NewValue(tftypes.String, "alice"),
+	"permission": tftypes.NewValue(tftypes.String, "write"),
+}
+
+func TestCachePermissionResource_Create(t *testing.T) {
+	server := newTestPermissionServer(t, map[string]string{})
+	r, s := newTestResource(t, NewCachePermissionResource, server.URL)
+
+	plan := withTestResourceAttr(t, s, newTestResourceValue(t, s, testAlicePermission),
+		"id", tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.permissions["alice"] != "write" {
+		t.Errorf("expected alice to be granted write, got %q", server.permissions["alice"])
+	}
+
+	var result CachePermissionResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.ID.ValueString() != "my-cache/alice" {
+		t.Errorf("expected id 'my-cache/alice', got %q", result.ID.ValueString())
+	}
+}
+
+func TestCachePermissionResource_Read(t *testing.T) {
+	tests := []struct {
+		name             string
+		permissions      map[string]string
+		expectRemoved    bool
+		expectPermission string
+	}{
+		{name: "unchanged", permissions: map[string]string{"alice": "write"}, expectPermission: "write"},
+		{name: "case-insensitive match", permissions: map[string]string{"Alice": "write"}, expectPermission: "write"},
+		{name: "changed outside Terraform", permissions: map[string]string{"alice": "admin"}, expectPermission: "admin"},
+		{name: "revoked outside Terraform", permissions: map[string]string{"my-org/alice": "write"}, expectRemoved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestPermissionServer(t, tt.permissions)
+			r, s := newTestResource(t, NewCachePermissionResource, server.URL)
+
+			state := tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, testAlicePermission)}
+			resp := &resource.ReadResponse{State: state}
+
+			r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tt.expectRemoved {
+				t.Fatalf("expected removed=%v, got state %v", tt.expectRemoved, resp.State.Raw)
+			}
+			if tt.expectRemoved {
+				return
+			}
+
+			var result CachePermissionResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Permission.ValueString() != tt.expectPermission {
+				t.Errorf("expected permission %q, got %q", tt.expectPermission, result.Permission.ValueString())
+			}
+		})
+	}
+}
+
+func TestCachePermissionResource_Update(t *testing.T) {
+	server := newTestPermissionServer(t, map[string]string{"alice": "read"})
+	r, s := newTestResource(t, NewCachePermissionResource, server.URL)
+
+	state := newTestResourceValue(t, s, testAlicePermission)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: withTestResourceAttr(t, s, state, "permission", tftypes.NewValue(tftypes.String, "admin"))},
+		State: tfsdk.State{Schema: s, Raw: withTestResourceAttr(t, s, state, "permission", tftypes.NewValue(tftypes.String, "read"))},
+	}
+	resp := &resource.UpdateResponse{State: req.State}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.permissions["alice"] != "admin" {
+		t.Errorf("expected alice to have admin, got %q", server.permissions["alice"])
+	}
+	if len(server.requests) != 1 || server.requests[0] != "PUT /cache/my-cache/permissions/user/alice" {
+		t.Errorf("expected a single in-place update, got %v", server.requests)
+	}
+}
+
+func TestCachePermissionResource_ImportState(t *testing.T) {
+	tests := []struct {
+		id          string
+		expectErr   bool
+		expectCache string
+		expectUser  string
+		expectTeam  string
+	}{
+		{id: "my-cache/alice", expectCache: "my-cache", expectUser: "alice"},
+		{id: "my-cache/my-org/ci", expectCache: "my-cache", expectTeam: "my-org/ci"},
+		{id: "my-cache", expectErr: true},
+		{id: "/alice", expectErr: true},
+		{id: "my-cache/", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			r, s := newTestResource(t, NewCachePermissionResource, "")
+			resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+			r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: tt.id}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Fatalf("expected error=%v, got diagnostics: %v", tt.expectErr, resp.Diagnostics)
+			}
+			if tt.expectErr {
+				return
+			}
+
+			var result CachePermissionResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Cache.ValueString() != tt.expectCache {
+				t.Errorf("expected cache %q, got %q", tt.expectCache, result.Cache.ValueString())
+			}
+			if result.Username.ValueString() != tt.expectUser || result.Team.ValueString() != tt.expectTeam {
+				t.Errorf("expected username %q and team %q, got %q and %q",
+					tt.expectUser, tt.expectTeam, result.Username.ValueString(), result.Team.ValueString())
+			}
+		})
+	}
+}
@@ -5,14 +5,25 @@ package provider
 
 import (
 	"context"
-
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +32,44 @@ var (
 	_ resource.Resource                = &CacheResource{}
 	_ resource.ResourceWithConfigure   = &CacheResource{}
 	_ resource.ResourceWithImportState = &CacheResource{}
+	_ resource.ResourceWithModifyPlan  = &CacheResource{}
+)
+
+const (
+	// signingKeyModeCachix lets Cachix generate and manage the signing key.
+	signingKeyModeCachix = "cachix"
+	// signingKeyModeSelf leaves signing to keys held by the cache owner.
+	signingKeyModeSelf = "self"
+)
+
+const (
+	// deletionPolicyDelete deletes the cache on destroy.
+	deletionPolicyDelete = "delete"
+	// deletionPolicyRetain removes the cache from state but keeps it in Cachix.
+	deletionPolicyRetain = "retain"
+	// deletionPolicyProtect fails any destroy of the cache.
+	deletionPolicyProtect = "protect"
+)
+
+// compressionMethods lists the NAR compression methods supported by Cachix.
+var compressionMethods = []string{"xz", "zstd"}
+
+// defaultCompression is the compression method Cachix uses for caches that do
+// not set one.
+const defaultCompression = "xz"
+
+const (
+	// defaultCacheCreateTimeout bounds cache creation, including retries.
+	defaultCacheCreateTimeout = 10 * time.Minute
+	// defaultCacheReadTimeout bounds reading a cache, including retries.
+	defaultCacheReadTimeout = 5 * time.Minute
+	// defaultCacheUpdateTimeout bounds updating cache settings, including retries.
+	defaultCacheUpdateTimeout = 10 * time.Minute
+	// defaultCacheDeleteTimeout bounds cache deletion, including retries.
+	defaultCacheDeleteTimeout = 10 * time.Minute
+	// cachePlanCheckTimeout bounds the API lookups made while planning, so
+	// that plans without access to the API are not held up by retries.
+	cachePlanCheckTimeout = 15 * time.Second
 )
 
 // NewCacheResource creates a new cache resource instance.
@@ -35,11 +84,80 @@ type CacheResource struct {
 
 // CacheResourceModel describes the resource data model.
 type CacheResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	IsPublic          types.Bool   `tfsdk:"is_public"`
-	URI               types.String `tfsdk:"uri"`
-	PublicSigningKeys types.List   `tfsdk:"public_signing_keys"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	IsPublic          types.Bool     `tfsdk:"is_public"`
+	URI               types.String   `tfsdk:"uri"`
+	PublicSigningKeys types.List     `tfsdk:"public_signing_keys"`
+	SigningKeyMode    types.String   `tfsdk:"signing_key_mode"`
+	Priority          types.Int64    `tfsdk:"priority"`
+	RetentionDays     types.Int64    `tfsdk:"retention_days"`
+	MaxStorageSizeGB  types.Int64    `tfsdk:"max_storage_size_gb"`
+	Compression       types.String   `tfsdk:"compression"`
+	DeletionPolicy    types.String   `tfsdk:"deletion_policy"`
+	ForceDestroy      types.Bool     `tfsdk:"force_destroy"`
+	AdoptExisting     types.Bool     `tfsdk:"adopt_existing"`
+	CreatedAt         types.String   `tfsdk:"created_at"`
+	Owner             types.String   `tfsdk:"owner"`
+	StorageBytes      types.Int64    `tfsdk:"storage_bytes"`
+	StorePathCount    types.Int64    `tfsdk:"store_path_count"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+var _ CacheModel = &CacheResourceModel{}
+
+// SetID sets the id attribute.
+func (m *CacheResourceModel) SetID(v types.String) { m.ID = v }
+
+// SetName sets the name attribute.
+func (m *CacheResourceModel) SetName(v types.String) { m.Name = v }
+
+// SetIsPublic sets the is_public attribute.
+func (m *CacheResourceModel) SetIsPublic(v types.Bool) { m.IsPublic = v }
+
+// SetURI sets the uri attribute.
+func (m *CacheResourceModel) SetURI(v types.String) { m.URI = v }
+
+// SetPublicSigningKeys sets the public_signing_keys attribute.
+func (m *CacheResourceModel) SetPublicSigningKeys(v types.List) { m.PublicSigningKeys = v }
+
+// SetPriority sets the priority attribute.
+func (m *CacheResourceModel) SetPriority(v types.Int64) { m.Priority = v }
+
+// SetRetentionDays sets the retention_days attribute.
+func (m *CacheResourceModel) SetRetentionDays(v types.Int64) { m.RetentionDays = v }
+
+// SetMaxStorageSizeGB sets the max_storage_size_gb attribute.
+func (m *CacheResourceModel) SetMaxStorageSizeGB(v types.Int64) { m.MaxStorageSizeGB = v }
+
+// SetCompression sets the compression attribute.
+func (m *CacheResourceModel) SetCompression(v types.String) { m.Compression = v }
+
+// SetCreatedAt sets the created_at attribute.
+func (m *CacheResourceModel) SetCreatedAt(v types.String) { m.CreatedAt = v }
+
+// SetOwner sets the owner attribute.
+func (m *CacheResourceModel) SetOwner(v types.String) { m.Owner = v }
+
+// SetStorageBytes sets the storage_bytes attribute.
+func (m *CacheResourceModel) SetStorageBytes(v types.Int64) { m.StorageBytes = v }
+
+// SetStorePathCount sets the store_path_count attribute.
+func (m *CacheResourceModel) SetStorePathCount(v types.Int64) { m.StorePathCount = v }
+
+// nullUnreportedSettings sets the computed settings that were neither
+// configured nor reported by the API to null, as state cannot hold unknown
+// values after apply.
+func (m *CacheResourceModel) nullUnreportedSettings() {
+	if m.Priority.IsUnknown() {
+		m.Priority = types.Int64Null()
+	}
+	if m.RetentionDays.IsUnknown() {
+		m.RetentionDays = types.Int64Null()
+	}
+	if m.MaxStorageSizeGB.IsUnknown() {
+		m.MaxStorageSizeGB = types.Int64Null()
+	}
 }
 
 // Metadata returns the resource type name.
@@ -62,7 +180,7 @@ func (r *CacheResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The name of the cache. Must be lowercase alphanumeric with hyphens, starting with a letter.",
+				MarkdownDescription: "The name of the cache. Must be lowercase alphanumeric with hyphens, starting with a letter. Cache names are global on Cachix; planning fails if the name is taken by another account.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -72,9 +190,83 @@ func (r *CacheResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
-				MarkdownDescription: "Whether the cache is publicly readable. Defaults to `true`.",
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+				MarkdownDescription: "Whether the cache is publicly readable. Defaults to `true`. Can be changed in place.",
+			},
+			"priority": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The substituter priority advertised in the cache's `nix-cache-info`. Lower values are preferred; `cache.nixos.org` uses `40`. Defaults to the Cachix default when unset. Can be changed in place.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retention_days": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of days store paths are kept before being garbage collected. Must be allowed by the subscription plan of the account. The provider does not check this when planning; the Cachix API rejects values the plan does not allow when the change is applied. Defaults to the plan default when unset. Can be changed in place.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_storage_size_gb": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum storage size of the cache in GB before the oldest store paths are garbage collected. Must be allowed by the subscription plan of the account. The provider does not check this when planning; the Cachix API rejects values the plan does not allow when the change is applied. Defaults to the plan default when unset. Can be changed in place.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"compression": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The compression method used for NARs pushed to the cache: `xz` for smaller NARs or `zstd` for faster compression and decompression. Uses the Cachix default, `xz`, when unset; removing it resets the cache to the default. Can be changed in place; NARs already in the cache keep their compression.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(compressionMethods...),
+				},
+			},
+			"deletion_policy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(deletionPolicyDelete),
+				MarkdownDescription: "What happens to the cache when the resource is destroyed: `delete` deletes the cache and all of its store paths, `retain` removes it from Terraform state but keeps it in Cachix, and `protect` fails the destroy. The policy must be applied before it takes effect on a destroy. Defaults to `delete`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(deletionPolicyDelete, deletionPolicyRetain, deletionPolicyProtect),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to adopt the cache into Terraform state when a cache with the same name already exists and is owned by the authenticated account, instead of failing. The adopted cache is updated to match the configuration. Its signing key mode is not reported by the Cachix API, so `signing_key_mode` is recorded as configured without being checked. Defaults to `false`.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to delete the cache even if it still holds store paths. When `false`, destroying the cache fails if it holds store paths or if the Cachix API does not report its usage. Must be applied before it takes effect on a destroy. Defaults to `false`.",
+			},
+			"signing_key_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(signingKeyModeCachix),
+				MarkdownDescription: "Who manages the signing key of the cache: `cachix` to have Cachix generate and sign with its own key, or `self` to create the cache without one and sign store paths with keys you hold. Defaults to `cachix`. The mode only takes effect when the cache is created and is not reported by the Cachix API, so it is recorded in Terraform state only and changes made outside Terraform are not detected. Changing this forces a new cache to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						signingKeyModeRequiresReplace,
+						"Changing the signing key mode requires recreating the cache.",
+						"Changing the signing key mode requires recreating the cache.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(signingKeyModeCachix, signingKeyModeSelf),
 				},
 			},
 			"uri": schema.StringAttribute{
@@ -84,12 +276,47 @@ func (r *CacheResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the cache was created, as an RFC 3339 timestamp.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The account that owns the cache.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The storage used by the cache in bytes, as of the last refresh.",
+			},
+			"store_path_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of store paths in the cache, as of the last refresh.",
+			},
 			"public_signing_keys": schema.ListAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of public signing keys for use in nix.conf.",
+				MarkdownDescription: "List of public signing keys for use in nix.conf. With `signing_key_mode = \"self\"` this lists the keys registered for the cache.",
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "How long to wait for the cache to be created, including retries, as a duration such as \"30s\" or \"10m\". Defaults to 10m.",
+				ReadDescription:   "How long to wait for the cache to be read, including retries, as a duration such as \"30s\" or \"5m\". Defaults to 5m.",
+				UpdateDescription: "How long to wait for the cache settings to be updated, including retries, as a duration such as \"30s\" or \"10m\". Defaults to 10m.",
+				DeleteDescription: "How long to wait for the cache to be deleted, including retries, as a duration such as \"30s\" or \"10m\". Defaults to 10m.",
+			}),
+		},
 	}
 }
 
@@ -111,26 +338,83 @@ func (r *CacheResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	timeout, diags := data.Timeouts.Create(ctx, defaultCacheCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating cache", map[string]any{
-		"name":      data.Name.ValueString(),
-		"is_public": data.IsPublic.ValueBool(),
+		"name":             data.Name.ValueString(),
+		"is_public":        data.IsPublic.ValueBool(),
+		"signing_key_mode": data.SigningKeyMode.ValueString(),
 	})
 
-	cache, err := r.client.CreateCache(ctx, data.Name.ValueString(), data.IsPublic.ValueBool())
+	var opts []CreateCacheOption
+	if data.SigningKeyMode.ValueString() == signingKeyModeSelf {
+		opts = append(opts, WithSelfManagedSigningKey())
+	}
+	if !data.Priority.IsNull() && !data.Priority.IsUnknown() {
+		opts = append(opts, WithPriority(data.Priority.ValueInt64()))
+	}
+	if data.RetentionDays.ValueInt64() > 0 || data.MaxStorageSizeGB.ValueInt64() > 0 {
+		opts = append(opts, WithRetention(data.RetentionDays.ValueInt64(), data.MaxStorageSizeGB.ValueInt64()))
+	}
+	if !data.Compression.IsNull() {
+		opts = append(opts, WithCompression(data.Compression.ValueString()))
+	}
+
+	cache, err := r.client.CreateCache(ctx, data.Name.ValueString(), data.IsPublic.ValueBool(), opts...)
+	if data.AdoptExisting.ValueBool() && IsConflictError(err) {
+		tflog.Info(ctx, "Cache already exists, adopting it", map[string]any{
+			"name": data.Name.ValueString(),
+		})
+
+		cache, err = r.adoptExistingCache(ctx, data, &resp.Diagnostics)
+		if errors.Is(err, errCacheNotOwned) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Cache Owned by Another Account",
+				fmt.Sprintf("The cache '%s' already exists but cannot be adopted because it is not owned by the authenticated account. "+
+					"Choose a different cache name.\n\nDetails: %s",
+					data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+		if errors.Is(err, errCacheOwnerUnknown) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Cache Owner Unknown",
+				fmt.Sprintf("The cache '%s' already exists but cannot be adopted because the Cachix API did not report its owner, "+
+					"so it cannot be confirmed to belong to the authenticated account. "+
+					"If it does, import it with terraform import instead.",
+					data.Name.ValueString()),
+			)
+			return
+		}
+	}
 	errorHandler := &APIErrorHandler{
 		Diagnostics:  &resp.Diagnostics,
 		ResourceType: "cache",
 		ResourceName: data.Name.ValueString(),
 		Operation:    "create",
 	}
-	if errorHandler.Handle(err) {
+	if errorHandler.Handle(err) || resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.ID, data.Name, data.IsPublic, data.URI, data.PublicSigningKeys = mapCacheToState(ctx, cache, &resp.Diagnostics)
+	// The compression method is not computed, so the applied value must
+	// match the configuration even when the API reports its default
+	compression := data.Compression
+	mapCacheToState(ctx, cache, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.Compression = compression
+	data.nullUnreportedSettings()
 
 	tflog.Trace(ctx, "Created cache", map[string]any{
 		"name": data.Name.ValueString(),
@@ -153,6 +437,15 @@ func (r *CacheResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	timeout, diags := data.Timeouts.Read(ctx, defaultCacheReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Reading cache", map[string]any{
 		"name": data.Name.ValueString(),
 	})
@@ -174,10 +467,16 @@ func (r *CacheResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	data.ID, data.Name, data.IsPublic, data.URI, data.PublicSigningKeys = mapCacheToState(ctx, cache, &resp.Diagnostics)
+	// The API reports the default compression method of a cache that does
+	// not set one, which is not drift from an unset compression
+	compression := data.Compression
+	mapCacheToState(ctx, cache, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if compression.IsNull() && data.Compression.ValueString() == defaultCompression {
+		data.Compression = compression
+	}
 
 	tflog.Trace(ctx, "Read cache", map[string]any{
 		"name": data.Name.ValueString(),
@@ -187,12 +486,150 @@ func (r *CacheResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Update is not supported for cache resources as all attributes require replacement.
+// Update changes the settings of an existing cache in place.
 func (r *CacheResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"The cachix_cache resource does not support updates. All attribute changes require resource replacement (ForceNew).",
+	var data, state CacheResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultCacheUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	update := cacheUpdateRequest(data, state)
+
+	tflog.Debug(ctx, "Updating cache", map[string]any{
+		"name":                data.Name.ValueString(),
+		"is_public":           data.IsPublic.ValueBool(),
+		"priority":            data.Priority.ValueInt64(),
+		"retention_days":      data.RetentionDays.ValueInt64(),
+		"max_storage_size_gb": data.MaxStorageSizeGB.ValueInt64(),
+		"compression":         data.Compression.ValueString(),
+	})
+
+	var (
+		cache *Cache
+		err   error
 	)
+	if update.IsEmpty() {
+		// Only Terraform-side settings such as timeouts changed
+		cache, err = r.client.GetCache(ctx, data.Name.ValueString())
+	} else {
+		cache, err = r.client.UpdateCache(ctx, data.Name.ValueString(), update)
+	}
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache",
+		ResourceName: data.Name.ValueString(),
+		Operation:    "update",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	// As in Create, the applied compression method must match the plan
+	compression := data.Compression
+	mapCacheToState(ctx, cache, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Compression = compression
+	data.nullUnreportedSettings()
+
+	tflog.Trace(ctx, "Updated cache", map[string]any{
+		"name":      data.Name.ValueString(),
+		"is_public": data.IsPublic.ValueBool(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+var (
+	// errCacheNotOwned is returned when adopting a cache that belongs to
+	// another account.
+	errCacheNotOwned = errors.New("cache is owned by another account")
+	// errCacheOwnerUnknown is returned when adopting a cache whose owner is
+	// not reported by the API.
+	errCacheOwnerUnknown = errors.New("owner of cache is unknown")
+)
+
+// adoptExistingCache takes over an existing cache of the authenticated account
+// and updates its settings to match the plan. The signing key mode is not
+// reported by the API, so it is taken from the plan without being checked.
+func (r *CacheResource) adoptExistingCache(ctx context.Context, plan CacheResourceModel, diags *diag.Diagnostics) (*Cache, error) {
+	name := plan.Name.ValueString()
+
+	user, err := r.client.GetUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for cache adoption: %w", err)
+	}
+
+	existing, err := r.client.GetCache(ctx, name)
+	if IsNotFoundError(err) {
+		// The name is taken but the cache is not visible to us
+		return nil, fmt.Errorf("%w: the cache is not visible to %q", errCacheNotOwned, user.Username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Owner == "" {
+		return nil, errCacheOwnerUnknown
+	}
+	if !strings.EqualFold(existing.Owner, user.Username) {
+		return nil, fmt.Errorf("%w: owned by %q, authenticated as %q", errCacheNotOwned, existing.Owner, user.Username)
+	}
+
+	var current CacheResourceModel
+	mapCacheToState(ctx, existing, &current, diags)
+
+	update := cacheUpdateRequest(plan, current)
+	if update.IsEmpty() {
+		return existing, nil
+	}
+
+	tflog.Debug(ctx, "Updating adopted cache to match configuration", map[string]any{
+		"name": name,
+	})
+
+	return r.client.UpdateCache(ctx, name, update)
+}
+
+// cacheUpdateRequest returns the settings that must change for the cache in
+// state to match the plan. Settings left unknown in the plan are kept.
+func cacheUpdateRequest(plan, state CacheResourceModel) UpdateCacheRequest {
+	var update UpdateCacheRequest
+	if !plan.IsPublic.Equal(state.IsPublic) {
+		update.IsPublic = plan.IsPublic.ValueBoolPointer()
+	}
+	if !plan.Priority.IsUnknown() && !plan.Priority.Equal(state.Priority) {
+		update.Priority = plan.Priority.ValueInt64Pointer()
+	}
+	if !plan.RetentionDays.IsUnknown() && !plan.RetentionDays.Equal(state.RetentionDays) {
+		update.RetentionDays = plan.RetentionDays.ValueInt64Pointer()
+	}
+	if !plan.MaxStorageSizeGB.IsUnknown() && !plan.MaxStorageSizeGB.Equal(state.MaxStorageSizeGB) {
+		update.MaxStorageSizeGB = plan.MaxStorageSizeGB.ValueInt64Pointer()
+	}
+	if !plan.Compression.Equal(state.Compression) {
+		// A removed compression method is reset to the Cachix default
+		compression := plan.Compression.ValueString()
+		update.Compression = &compression
+	}
+	return update
 }
 
 // Delete removes the cache resource.
@@ -204,21 +641,89 @@ func (r *CacheResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	switch data.DeletionPolicy.ValueString() {
+	case deletionPolicyProtect:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_policy"),
+			"Cache Deletion Protected",
+			fmt.Sprintf("The cache '%s' has deletion_policy = %q and was not deleted. "+
+				"To delete it, set deletion_policy to %q and apply before destroying, "+
+				"or set it to %q to remove the cache from Terraform state while keeping it in Cachix.",
+				data.Name.ValueString(), deletionPolicyProtect, deletionPolicyDelete, deletionPolicyRetain),
+		)
+		return
+	case deletionPolicyRetain:
+		tflog.Warn(ctx, "Removing cache from state without deleting it", map[string]any{
+			"name": data.Name.ValueString(),
+		})
+		resp.Diagnostics.AddWarning(
+			"Cache Retained",
+			fmt.Sprintf("The cache '%s' has deletion_policy = %q. It was removed from Terraform state but still exists in Cachix.",
+				data.Name.ValueString(), deletionPolicyRetain),
+		)
+		return
+	}
+
 	if !requireClient(r.client, &resp.Diagnostics) {
 		return
 	}
 
-	tflog.Debug(ctx, "Deleting cache", map[string]any{
-		"name": data.Name.ValueString(),
-	})
+	timeout, diags := data.Timeouts.Delete(ctx, defaultCacheDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	err := r.client.DeleteCache(ctx, data.Name.ValueString())
 	errorHandler := &APIErrorHandler{
 		Diagnostics:  &resp.Diagnostics,
 		ResourceType: "cache",
 		ResourceName: data.Name.ValueString(),
 		Operation:    "delete",
 	}
+
+	if !data.ForceDestroy.ValueBool() {
+		cache, err := r.client.GetCache(ctx, data.Name.ValueString())
+		if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+			if wasNotFound {
+				tflog.Warn(ctx, "Cache already deleted", map[string]any{
+					"name": data.Name.ValueString(),
+				})
+			}
+			return
+		}
+
+		empty, known := cache.IsEmpty()
+		if !known {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force_destroy"),
+				"Cache Usage Unknown",
+				fmt.Sprintf("The Cachix API did not report how many store paths the cache '%s' holds, so it cannot be confirmed to be empty. "+
+					"To delete it anyway, set force_destroy = true and apply before destroying.",
+					data.Name.ValueString()),
+			)
+			return
+		}
+		if !empty {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force_destroy"),
+				"Cache Not Empty",
+				fmt.Sprintf("The cache '%s' still holds %d store paths (%s) that would be lost. "+
+					"To delete it anyway, set force_destroy = true and apply before destroying.",
+					data.Name.ValueString(), *cache.StorePathCount, formatBytes(*cache.StorageBytes)),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Deleting cache", map[string]any{
+		"name":          data.Name.ValueString(),
+		"force_destroy": data.ForceDestroy.ValueBool(),
+	})
+
+	err := r.client.DeleteCache(ctx, data.Name.ValueString())
 	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
 		if wasNotFound {
 			tflog.Warn(ctx, "Cache already deleted", map[string]any{
@@ -233,11 +738,226 @@ func (r *CacheResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	})
 }
 
-// ImportState imports an existing cache into Terraform state.
+// ModifyPlan checks that the name of a new or renamed cache is not taken by
+// another account, so that it fails at plan time rather than partway through
+// an apply. Retention settings are left for the API to check against the
+// subscription plan of the account, as the provider does not know the limits
+// of each plan.
+func (r *CacheResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan CacheResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state CacheResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Only a renamed cache is replaced by a new one
+		if plan.Name.Equal(state.Name) {
+			return
+		}
+	}
+
+	if plan.Name.IsUnknown() {
+		tflog.Debug(ctx, "Cache name unknown until apply, skipping availability check")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cachePlanCheckTimeout)
+	defer cancel()
+
+	user, err := r.client.GetUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Check Cache Name Availability",
+			fmt.Sprintf("The provider could not look up the account to check whether the cache name '%s' is available. "+
+				"It will be checked by the Cachix API when applied.\n\nError: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	r.checkCacheNameAvailable(ctx, plan, user, &resp.Diagnostics)
+}
+
+// checkCacheNameAvailable reports an error when the name of a cache about to
+// be created is taken by another account, and a warning when the name cannot
+// be checked.
+func (r *CacheResource) checkCacheNameAvailable(ctx context.Context, plan CacheResourceModel, user *User, diags *diag.Diagnostics) {
+	name := plan.Name.ValueString()
+	existing, err := r.client.GetCache(ctx, name)
+	if IsNotFoundError(err) {
+		return
+	}
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Check Cache Name Availability",
+			fmt.Sprintf("The provider could not check whether the cache name '%s' is available. "+
+				"It will be checked by the Cachix API when applied.\n\nError: %s", name, err),
+		)
+		return
+	}
+
+	switch {
+	case existing.Owner == "":
+		diags.AddAttributeWarning(
+			path.Root("name"),
+			"Unable to Check Cache Name Availability",
+			fmt.Sprintf("The cache name '%s' is already in use, but the Cachix API did not report which account owns the cache, "+
+				"so creating it will fail. If it belongs to the authenticated account, import it to manage it with Terraform.",
+				name),
+		)
+	case !strings.EqualFold(existing.Owner, user.Username):
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Cache Name Unavailable",
+			fmt.Sprintf("The cache name '%s' is already taken by account %q. Cache names are global on Cachix, choose a different name.",
+				name, existing.Owner),
+		)
+	case !plan.AdoptExisting.ValueBool():
+		diags.AddAttributeWarning(
+			path.Root("name"),
+			"Cache Already Exists",
+			fmt.Sprintf("The cache '%s' already exists in account %q, so creating it will fail. "+
+				"Set adopt_existing = true or import the cache to manage it with Terraform.",
+				name, existing.Owner),
+		)
+	}
+}
+
+// signingKeyModeRequiresReplace forces replacement when the signing key mode
+// of an existing cache changes. The API does not report the mode, so it is
+// never refreshed by Read; imported caches have no recorded mode and setting
+// one for the first time only updates state.
+func signingKeyModeRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// ImportState imports an existing cache into Terraform state. The import ID
+// is the cache name, its URI (https://<name>.cachix.org) or owner/name.
 func (r *CacheResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Importing cache", map[string]any{
 		"id": req.ID,
 	})
 
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	owner, name, err := parseCacheImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a cache name such as \"my-cache\", a cache URI such as \"https://my-cache.cachix.org\" "+
+				"or \"owner/my-cache\", got %q: %s", req.ID, err),
+		)
+		return
+	}
+
+	nameResp := &validator.StringResponse{}
+	cacheNameValidator.ValidateString(ctx, validator.StringRequest{
+		Path:        path.Root("name"),
+		ConfigValue: types.StringValue(name),
+	}, nameResp)
+	if nameResp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The cache name %q in import ID %q is invalid: it %s.", name, req.ID, cacheNameValidator.Description(ctx)),
+		)
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultCacheReadTimeout)
+	defer cancel()
+
+	cache, err := r.client.GetCache(ctx, name)
+	if IsNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Cache Not Found",
+			fmt.Sprintf("The cache '%s' does not exist or is not visible to the authenticated account. "+
+				"Check the spelling of the import ID and that the configured auth token has access to the cache.", name),
+		)
+		return
+	}
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache",
+		ResourceName: name,
+		Operation:    "read",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	if owner != "" && cache.Owner == "" {
+		resp.Diagnostics.AddError(
+			"Cache Owner Unknown",
+			fmt.Sprintf("The import ID names %q as the owner of cache '%s', but the Cachix API did not report the owner of the cache, "+
+				"so it cannot be checked. Import the cache by name to skip the check.", owner, name),
+		)
+		return
+	}
+	if owner != "" && !strings.EqualFold(owner, cache.Owner) {
+		resp.Diagnostics.AddError(
+			"Cache Owner Mismatch",
+			fmt.Sprintf("The cache '%s' is owned by %q, not %q as given in the import ID.", name, cache.Owner, owner),
+		)
+		return
+	}
+
+	// Terraform-only settings start from their defaults so that importing
+	// does not plan an update for them. The signing key mode is left null,
+	// as the API does not report it: the first apply records the configured
+	// mode in place, without replacing the cache.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cache.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), cache.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_policy"), deletionPolicyDelete)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+}
+
+// parseCacheImportID extracts the cache name, and the owner if given, from an
+// import ID of the form name, https://<name>.cachix.org or owner/name.
+func parseCacheImportID(id string) (owner, name string, err error) {
+	id = strings.TrimSpace(id)
+
+	if strings.Contains(id, "://") {
+		u, err := url.Parse(id)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid cache URI: %w", err)
+		}
+		if u.Scheme != "https" {
+			return "", "", fmt.Errorf("cache URI must use https, got %q", u.Scheme)
+		}
+		if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			return "", "", errors.New("cache URI must not have a path, query or fragment")
+		}
+		name, ok := strings.CutSuffix(u.Hostname(), ".cachix.org")
+		if !ok || name == "" || strings.Contains(name, ".") {
+			return "", "", fmt.Errorf("cache URI host must be <name>.cachix.org, got %q", u.Hostname())
+		}
+		return "", name, nil
+	}
+
+	if owner, name, ok := strings.Cut(id, "/"); ok {
+		if owner == "" || name == "" || strings.Contains(name, "/") {
+			return "", "", errors.New("expected owner/name")
+		}
+		return owner, name, nil
+	}
+
+	if id == "" {
+		return "", "", errors.New("import ID is empty")
+	}
+
+	return "", id, nil
 }
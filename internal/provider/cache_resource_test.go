@@ -5,15 +5,197 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
+// newTestResourceValue builds a resource object value for the schema, using
+// null for any attribute or block not present in values.
+func newTestResourceValue(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrValues := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrValues[name] = v
+		} else {
+			attrValues[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return tftypes.NewValue(objectType, attrValues)
+}
+
+// newTestCacheResource returns a cache resource using a client for serverURL,
+// along with its schema.
+func newTestCacheResource(t *testing.T, serverURL string, opts ...ClientOption) (*CacheResource, schema.Schema) {
+	t.Helper()
+
+	r := &CacheResource{client: NewCachixClient(serverURL, "test-token", "1.0.0", opts...)}
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	return r, resp.Schema
+}
+
+// newTestResource returns the resource built by newResource, configured with
+// a client for serverURL that does not retry, along with its schema.
+func newTestResource(t *testing.T, newResource func() resource.Resource, serverURL string) (resource.Resource, schema.Schema) {
+	t.Helper()
+
+	r := newResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: NewCachixClient(serverURL, "test-token", "1.0.0", WithRetryMax(0)),
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("failed to configure resource: %v", configureResp.Diagnostics)
+	}
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	return r, resp.Schema
+}
+
+// testTimeoutsValue returns a timeouts block value with the given create,
+// read and delete durations, leaving empty ones and update null.
+func testTimeoutsValue(create, read, del string) tftypes.Value {
+	timeoutsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"read":   tftypes.String,
+		"update": tftypes.String,
+		"delete": tftypes.String,
+	}}
+	value := func(s string) tftypes.Value {
+		if s == "" {
+			return tftypes.NewValue(tftypes.String, nil)
+		}
+		return tftypes.NewValue(tftypes.String, s)
+	}
+
+	return tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+		"create": value(create),
+		"read":   value(read),
+		"update": value(""),
+		"delete": value(del),
+	})
+}
+
+// testCacheServer is an in-memory Cachix API serving a single cache.
+type testCacheServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	cache       Cache
+	user        User
+	updates     []map[string]any
+	userLookups int
+	deletes     int
+}
+
+// newTestCacheServer starts a fake Cachix API holding the given cache, owned
+// by a user on the free plan.
+func newTestCacheServer(t *testing.T, cache Cache) *testCacheServer {
+	t.Helper()
+
+	s := &testCacheServer{
+		cache: cache,
+		user:  User{ID: 1, Username: "testuser", SubscriptionPlan: "free"},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && r.URL.Path == "/user" {
+			s.userLookups++
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(s.user)
+			return
+		}
+
+		if r.URL.Path != "/cache/"+s.cache.Name {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(s.cache)
+		case http.MethodPatch:
+			var update map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			s.updates = append(s.updates, update)
+			if v, ok := update["isPublic"].(bool); ok {
+				s.cache.IsPublic = v
+			}
+			if v, ok := update["priority"].(float64); ok {
+				s.cache.Priority = ptr(int64(v))
+			}
+			if v, ok := update["retentionDays"].(float64); ok {
+				s.cache.RetentionDays = ptr(int64(v))
+			}
+			if v, ok := update["maxStorageSizeGB"].(float64); ok {
+				s.cache.MaxStorageSizeGB = ptr(int64(v))
+			}
+			if v, ok := update["compression"].(string); ok {
+				s.cache.Compression = &v
+				if v == "" {
+					s.cache.Compression = nil
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPost:
+			// The server always holds the cache, so its name is taken
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "cache already exists"})
+		case http.MethodDelete:
+			s.deletes++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// setCache replaces the cache, e.g. to simulate changes made outside Terraform.
+func (s *testCacheServer) setCache(cache Cache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = cache
+}
+
 // Unit Tests
 
 func TestCacheResource_Metadata(t *testing.T) {
@@ -44,6 +226,1192 @@ func TestCacheResource_Schema(t *testing.T) {
 			t.Errorf("expected '%s' attribute in schema", attr)
 		}
 	}
+
+	// Verify read-only attributes reported by the API
+	for _, attr := range []string{"created_at", "owner", "storage_bytes", "store_path_count"} {
+		a, ok := resp.Schema.Attributes[attr]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", attr)
+			continue
+		}
+		if !a.IsComputed() || a.IsOptional() || a.IsRequired() {
+			t.Errorf("expected '%s' to be read-only", attr)
+		}
+	}
+}
+
+func TestCacheResource_Schema_Timeouts(t *testing.T) {
+	_, s := newTestCacheResource(t, "")
+
+	if _, ok := s.Blocks["timeouts"]; !ok {
+		t.Fatal("expected 'timeouts' block in schema")
+	}
+}
+
+func TestCacheResource_Create_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	r, s := newTestCacheResource(t, server.URL)
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"name":      tftypes.NewValue(tftypes.String, "my-cache"),
+		"is_public": tftypes.NewValue(tftypes.Bool, true),
+		"timeouts":  testTimeoutsValue("50ms", "", ""),
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+	start := time.Now()
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected timeout error, got none")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Operation Timed Out" {
+		t.Errorf("expected 'Operation Timed Out', got %q", summary)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected create to stop at its timeout, took %v", elapsed)
+	}
+}
+
+func TestCacheResource_Read_InvalidTimeout(t *testing.T) {
+	r, s := newTestCacheResource(t, "http://127.0.0.1:0")
+	state := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "my-cache"),
+		"timeouts": testTimeoutsValue("", "soon", ""),
+	})
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid read timeout, got none")
+	}
+}
+
+func TestCacheResource_Schema_IsPublicUpdatesInPlace(t *testing.T) {
+	_, s := newTestCacheResource(t, "")
+
+	attr, ok := s.Attributes["is_public"].(schema.BoolAttribute)
+	if !ok {
+		t.Fatal("expected 'is_public' to be a bool attribute")
+	}
+	if len(attr.PlanModifiers) != 0 {
+		t.Errorf("expected no plan modifiers on 'is_public', got %d", len(attr.PlanModifiers))
+	}
+}
+
+// testCacheStateValue returns a cache resource value as stored in state.
+func testCacheStateValue(t *testing.T, s schema.Schema, name string, isPublic bool) tftypes.Value {
+	t.Helper()
+
+	return newTestResourceValue(t, s, map[string]tftypes.Value{
+		"id":        tftypes.NewValue(tftypes.String, name),
+		"name":      tftypes.NewValue(tftypes.String, name),
+		"is_public": tftypes.NewValue(tftypes.Bool, isPublic),
+		"uri":       tftypes.NewValue(tftypes.String, fmt.Sprintf("https://%s.cachix.org", name)),
+		"public_signing_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, name+".cachix.org-1:xxxx="),
+		}),
+	})
+}
+
+// withTestResourceAttr returns a copy of the resource value with one
+// attribute replaced.
+func withTestResourceAttr(t *testing.T, s schema.Schema, value tftypes.Value, name string, attr tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	var current map[string]tftypes.Value
+	if err := value.As(&current); err != nil {
+		t.Fatalf("failed to convert resource value: %v", err)
+	}
+
+	// Copy the attributes, as As shares the map backing value
+	values := make(map[string]tftypes.Value, len(current))
+	for k, v := range current {
+		values[k] = v
+	}
+	values[name] = attr
+
+	return newTestResourceValue(t, s, values)
+}
+
+func TestCacheResource_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		stateIsPublic bool
+		planIsPublic  bool
+		expectPatch   bool
+	}{
+		{name: "public to private", stateIsPublic: true, planIsPublic: false, expectPatch: true},
+		{name: "private to public", stateIsPublic: false, planIsPublic: true, expectPatch: true},
+		{name: "no setting changes", stateIsPublic: true, planIsPublic: true, expectPatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched bool
+			isPublic := tt.stateIsPublic
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPatch && r.URL.Path == "/cache/my-cache":
+					patched = true
+					var reqBody UpdateCacheRequest
+					if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+						t.Errorf("failed to decode request body: %v", err)
+					}
+					if reqBody.IsPublic != nil {
+						isPublic = *reqBody.IsPublic
+					}
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(Cache{
+						Name:              "my-cache",
+						URI:               "https://my-cache.cachix.org",
+						IsPublic:          isPublic,
+						PublicSigningKeys: []string{"my-cache.cachix.org-1:xxxx="},
+					})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestCacheResource(t, server.URL)
+			state := testCacheStateValue(t, s, "my-cache", tt.stateIsPublic)
+			plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"id":                  tftypes.NewValue(tftypes.String, "my-cache"),
+				"name":                tftypes.NewValue(tftypes.String, "my-cache"),
+				"is_public":           tftypes.NewValue(tftypes.Bool, tt.planIsPublic),
+				"uri":                 tftypes.NewValue(tftypes.String, "https://my-cache.cachix.org"),
+				"public_signing_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+			})
+
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+				State: tfsdk.State{Schema: s, Raw: state},
+			}
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+			r.Update(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if patched != tt.expectPatch {
+				t.Errorf("expected PATCH called=%v, got %v", tt.expectPatch, patched)
+			}
+
+			var result CacheResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error reading state: %v", resp.Diagnostics)
+			}
+			if result.IsPublic.ValueBool() != tt.planIsPublic {
+				t.Errorf("expected is_public %v in state, got %v", tt.planIsPublic, result.IsPublic.ValueBool())
+			}
+			if len(result.PublicSigningKeys.Elements()) != 1 {
+				t.Errorf("expected 1 public signing key in state, got %d", len(result.PublicSigningKeys.Elements()))
+			}
+		})
+	}
+}
+
+func TestCacheResource_Update_Priority(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org", IsPublic: true, Priority: ptr[int64](41)})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	state = withTestResourceAttr(t, s, state, "priority", tftypes.NewValue(tftypes.Number, 41))
+	plan := withTestResourceAttr(t, s, state, "priority", tftypes.NewValue(tftypes.Number, 30))
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(server.updates) != 1 || len(server.updates[0]) != 1 || server.updates[0]["priority"] != float64(30) {
+		t.Errorf("expected a single update of priority to 30, got %v", server.updates)
+	}
+
+	var result CacheResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.Priority.ValueInt64() != 30 {
+		t.Errorf("expected priority 30 in state, got %d", result.Priority.ValueInt64())
+	}
+}
+
+func TestCacheResource_Update_Retention(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org", IsPublic: true})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	state = withTestResourceAttr(t, s, state, "retention_days", tftypes.NewValue(tftypes.Number, 0))
+	state = withTestResourceAttr(t, s, state, "max_storage_size_gb", tftypes.NewValue(tftypes.Number, 0))
+	plan := withTestResourceAttr(t, s, state, "retention_days", tftypes.NewValue(tftypes.Number, 14))
+	plan = withTestResourceAttr(t, s, plan, "max_storage_size_gb", tftypes.NewValue(tftypes.Number, 3))
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(server.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(server.updates))
+	}
+	if server.updates[0]["retentionDays"] != float64(14) || server.updates[0]["maxStorageSizeGB"] != float64(3) {
+		t.Errorf("expected retentionDays=14 and maxStorageSizeGB=3, got %v", server.updates[0])
+	}
+
+	var result CacheResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.RetentionDays.ValueInt64() != 14 || result.MaxStorageSizeGB.ValueInt64() != 3 {
+		t.Errorf("expected retention 14 days and 3 GB in state, got %d and %d",
+			result.RetentionDays.ValueInt64(), result.MaxStorageSizeGB.ValueInt64())
+	}
+}
+
+func TestCacheResource_Update_Compression(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org", IsPublic: true, Compression: ptr("xz")})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	state = withTestResourceAttr(t, s, state, "compression", tftypes.NewValue(tftypes.String, "xz"))
+
+	steps := []struct {
+		name     string
+		planned  tftypes.Value
+		sent     string
+		expected types.String
+	}{
+		{name: "change", planned: tftypes.NewValue(tftypes.String, "zstd"), sent: "zstd", expected: types.StringValue("zstd")},
+		{name: "clear", planned: tftypes.NewValue(tftypes.String, nil), sent: "", expected: types.StringNull()},
+	}
+
+	for i, step := range steps {
+		plan := withTestResourceAttr(t, s, state, "compression", step.planned)
+
+		req := resource.UpdateRequest{
+			Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+			State: tfsdk.State{Schema: s, Raw: state},
+		}
+		resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+		r.Update(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", step.name, resp.Diagnostics)
+		}
+		if len(server.updates) != i+1 || len(server.updates[i]) != 1 || server.updates[i]["compression"] != step.sent {
+			t.Errorf("%s: expected a single update of compression to %q, got %v", step.name, step.sent, server.updates)
+		}
+
+		var result CacheResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+		if !result.Compression.Equal(step.expected) {
+			t.Errorf("%s: expected compression %s in state, got %s", step.name, step.expected, result.Compression)
+		}
+
+		state = resp.State.Raw
+	}
+
+	if server.cache.Compression != nil {
+		t.Errorf("expected compression to be reset to the default, got %q", *server.cache.Compression)
+	}
+}
+
+func TestCacheResource_Schema_CompressionValidation(t *testing.T) {
+	_, s := newTestCacheResource(t, "")
+
+	attr, ok := s.Attributes["compression"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected 'compression' to be a string attribute")
+	}
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"xz", true},
+		{"zstd", true},
+		{"gzip", false},
+		{"ZSTD", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("compression"),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() == tt.valid {
+				t.Errorf("value %q: expected valid=%v, got diagnostics %v", tt.value, tt.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCacheResource_ModifyPlan_RetentionLeftToAPI(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache"})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	plan := withTestResourceAttr(t, s, state, "retention_days", tftypes.NewValue(tftypes.Number, 9999))
+	plan = withTestResourceAttr(t, s, plan, "max_storage_size_gb", tftypes.NewValue(tftypes.Number, 9999))
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got: %v", resp.Diagnostics)
+	}
+	if server.userLookups != 0 {
+		t.Errorf("expected no API calls when updating retention settings, got %d user lookups", server.userLookups)
+	}
+}
+
+func TestCacheResource_ModifyPlan_Destroy(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache"})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(state.Type(), nil)},
+		State: tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.userLookups != 0 {
+		t.Errorf("expected no API calls when destroying, got %d user lookups", server.userLookups)
+	}
+}
+
+func TestCacheResource_ModifyPlan_UserLookupFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	r, s := newTestCacheResource(t, server.URL)
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"name":           tftypes.NewValue(tftypes.String, "my-cache"),
+		"retention_days": tftypes.NewValue(tftypes.Number, 14),
+	})
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(plan.Type(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got %d", resp.Diagnostics.WarningsCount())
+	}
+}
+
+func TestCacheResource_ModifyPlan_NameAvailability(t *testing.T) {
+	tests := []struct {
+		name           string
+		cacheName      tftypes.Value
+		stateName      string // empty means the cache is being created
+		owner          string // empty means the name is available
+		ownerUnknown   bool   // the cache exists but its owner is not reported
+		adoptExisting  bool
+		expectLookup   bool
+		expectError    string
+		expectWarnings int
+	}{
+		{
+			name:         "available",
+			cacheName:    tftypes.NewValue(tftypes.String, "my-cache"),
+			expectLookup: true,
+		},
+		{
+			name:         "taken by another account",
+			cacheName:    tftypes.NewValue(tftypes.String, "my-cache"),
+			owner:        "someone-else",
+			expectLookup: true,
+			expectError:  "Cache Name Unavailable",
+		},
+		{
+			name:           "owned by authenticated account",
+			cacheName:      tftypes.NewValue(tftypes.String, "my-cache"),
+			owner:          "testuser",
+			expectLookup:   true,
+			expectWarnings: 1,
+		},
+		{
+			name:          "owned by authenticated account with adopt_existing",
+			cacheName:     tftypes.NewValue(tftypes.String, "my-cache"),
+			owner:         "testuser",
+			adoptExisting: true,
+			expectLookup:  true,
+		},
+		{
+			name:           "owner not reported",
+			cacheName:      tftypes.NewValue(tftypes.String, "my-cache"),
+			ownerUnknown:   true,
+			expectLookup:   true,
+			expectWarnings: 1,
+		},
+		{
+			name:      "unknown name",
+			cacheName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			owner:     "someone-else",
+		},
+		{
+			name:         "renamed to a name taken by another account",
+			cacheName:    tftypes.NewValue(tftypes.String, "my-cache"),
+			stateName:    "old-cache",
+			owner:        "someone-else",
+			expectLookup: true,
+			expectError:  "Cache Name Unavailable",
+		},
+		{
+			name:      "renamed to a name unknown until apply",
+			cacheName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			stateName: "old-cache",
+			owner:     "someone-else",
+		},
+		{
+			name:      "existing cache not renamed",
+			cacheName: tftypes.NewValue(tftypes.String, "my-cache"),
+			stateName: "my-cache",
+			owner:     "someone-else",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userLookups, cacheLookups int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/user":
+					userLookups++
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(User{ID: 1, Username: "testuser", SubscriptionPlan: "free"})
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+					cacheLookups++
+					if tt.owner == "" && !tt.ownerUnknown {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(Cache{Name: "my-cache", Owner: tt.owner})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestCacheResource(t, server.URL)
+			plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"name":           tt.cacheName,
+				"is_public":      tftypes.NewValue(tftypes.Bool, true),
+				"adopt_existing": tftypes.NewValue(tftypes.Bool, tt.adoptExisting),
+			})
+
+			state := tftypes.NewValue(plan.Type(), nil)
+			if tt.stateName != "" {
+				state = testCacheStateValue(t, s, tt.stateName, true)
+			}
+
+			req := resource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+				State: tfsdk.State{Schema: s, Raw: state},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if (userLookups > 0) != tt.expectLookup || (cacheLookups > 0) != tt.expectLookup {
+				t.Errorf("expected lookups=%v, got %d user and %d cache lookups", tt.expectLookup, userLookups, cacheLookups)
+			}
+			if resp.Diagnostics.WarningsCount() != tt.expectWarnings {
+				t.Errorf("expected %d warnings, got: %v", tt.expectWarnings, resp.Diagnostics)
+			}
+
+			if tt.expectError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error, got none")
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.expectError {
+				t.Errorf("expected %q, got %q", tt.expectError, summary)
+			}
+		})
+	}
+}
+
+func TestCacheResource_ModifyPlan_Offline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	r, s := newTestCacheResource(t, serverURL, WithRetryMax(0))
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"name":      tftypes.NewValue(tftypes.String, "my-cache"),
+		"is_public": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(plan.Type(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected offline plans to succeed, got: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestCacheResource_Schema_DeletionPolicyValidation(t *testing.T) {
+	tests := []struct {
+		value     string
+		expectErr bool
+	}{
+		{value: "delete"},
+		{value: "retain"},
+		{value: "protect"},
+		{value: "keep", expectErr: true},
+		{value: "", expectErr: true},
+	}
+
+	r := NewCacheResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	attr, ok := schemaResp.Schema.Attributes["deletion_policy"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected deletion_policy to be a string attribute")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("deletion_policy"),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("expected error=%v, got diagnostics: %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Delete_DeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		expectDeletes int
+		expectErr     bool
+		expectWarning bool
+	}{
+		{
+			name:          "delete",
+			policy:        deletionPolicyDelete,
+			expectDeletes: 1,
+		},
+		{
+			name:          "unset in state deletes",
+			expectDeletes: 1,
+		},
+		{
+			name:          "retain",
+			policy:        deletionPolicyRetain,
+			expectWarning: true,
+		},
+		{
+			name:      "protect",
+			policy:    deletionPolicyProtect,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestCacheServer(t, Cache{Name: "my-cache", IsPublic: true, StorePathCount: ptr[int64](0), StorageBytes: ptr[int64](0)})
+			r, s := newTestCacheResource(t, server.URL)
+
+			stateValue := testCacheStateValue(t, s, "my-cache", true)
+			if tt.policy != "" {
+				stateValue = withTestResourceAttr(t, s, stateValue, "deletion_policy", tftypes.NewValue(tftypes.String, tt.policy))
+			}
+
+			state := tfsdk.State{Schema: s, Raw: stateValue}
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Fatalf("expected error=%v, got diagnostics: %v", tt.expectErr, resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != tt.expectWarning {
+				t.Errorf("expected warning=%v, got diagnostics: %v", tt.expectWarning, resp.Diagnostics)
+			}
+			if server.deletes != tt.expectDeletes {
+				t.Errorf("expected %d delete calls, got %d", tt.expectDeletes, server.deletes)
+			}
+			if tt.expectErr && resp.Diagnostics.Errors()[0].Summary() != "Cache Deletion Protected" {
+				t.Errorf("expected deletion protection error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Delete_ForceDestroy(t *testing.T) {
+	tests := []struct {
+		name          string
+		cache         Cache
+		forceDestroy  bool
+		expectDeletes int
+		expectErr     string
+	}{
+		{
+			name:          "empty cache",
+			cache:         Cache{Name: "my-cache", StorePathCount: ptr[int64](0), StorageBytes: ptr[int64](0)},
+			expectDeletes: 1,
+		},
+		{
+			name:      "non-empty cache",
+			cache:     Cache{Name: "my-cache", StorePathCount: ptr[int64](42), StorageBytes: ptr[int64](3 << 29)},
+			expectErr: "42 store paths (1.5 GiB)",
+		},
+		{
+			name:          "non-empty cache with force_destroy",
+			cache:         Cache{Name: "my-cache", StorePathCount: ptr[int64](42), StorageBytes: ptr[int64](3 << 29)},
+			forceDestroy:  true,
+			expectDeletes: 1,
+		},
+		{
+			name:      "usage not reported",
+			cache:     Cache{Name: "my-cache"},
+			expectErr: "did not report how many store paths",
+		},
+		{
+			name:      "storage not reported",
+			cache:     Cache{Name: "my-cache", StorePathCount: ptr[int64](0)},
+			expectErr: "did not report how many store paths",
+		},
+		{
+			name:          "usage not reported with force_destroy",
+			cache:         Cache{Name: "my-cache"},
+			forceDestroy:  true,
+			expectDeletes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestCacheServer(t, tt.cache)
+			r, s := newTestCacheResource(t, server.URL)
+
+			stateValue := withTestResourceAttr(t, s, testCacheStateValue(t, s, "my-cache", true),
+				"force_destroy", tftypes.NewValue(tftypes.Bool, tt.forceDestroy))
+
+			state := tfsdk.State{Schema: s, Raw: stateValue}
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+			if tt.expectErr == "" && resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if tt.expectErr != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.expectErr) {
+					t.Errorf("expected error to contain %q, got: %s", tt.expectErr, detail)
+				}
+			}
+			if server.deletes != tt.expectDeletes {
+				t.Errorf("expected %d delete calls, got %d", tt.expectDeletes, server.deletes)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Read_DetectsDrift(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org", IsPublic: true, Priority: ptr[int64](30)})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	state = withTestResourceAttr(t, s, state, "priority", tftypes.NewValue(tftypes.Number, 30))
+
+	// Someone changes the settings in the web UI
+	server.setCache(Cache{
+		Name:             "my-cache",
+		URI:              "https://my-cache.cachix.org",
+		IsPublic:         false,
+		Priority:         ptr[int64](50),
+		RetentionDays:    ptr[int64](7),
+		MaxStorageSizeGB: ptr[int64](2),
+	})
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var result CacheResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.Priority.ValueInt64() != 50 {
+		t.Errorf("expected priority 50 in state, got %d", result.Priority.ValueInt64())
+	}
+	if result.IsPublic.ValueBool() {
+		t.Error("expected is_public false in state")
+	}
+	if result.RetentionDays.ValueInt64() != 7 {
+		t.Errorf("expected retention_days 7 in state, got %d", result.RetentionDays.ValueInt64())
+	}
+	if result.MaxStorageSizeGB.ValueInt64() != 2 {
+		t.Errorf("expected max_storage_size_gb 2 in state, got %d", result.MaxStorageSizeGB.ValueInt64())
+	}
+}
+
+func TestCacheResource_Read_DefaultCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       tftypes.Value
+		reported    string
+		expectNull  bool
+		expectValue string
+	}{
+		{name: "unset reported as default", state: tftypes.NewValue(tftypes.String, nil), reported: "xz", expectNull: true},
+		{name: "unset changed outside Terraform", state: tftypes.NewValue(tftypes.String, nil), reported: "zstd", expectValue: "zstd"},
+		{name: "set to default", state: tftypes.NewValue(tftypes.String, "xz"), reported: "xz", expectValue: "xz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org", Compression: ptr(tt.reported)})
+
+			r, s := newTestCacheResource(t, server.URL)
+			state := withTestResourceAttr(t, s, testCacheStateValue(t, s, "my-cache", false), "compression", tt.state)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+			r.Read(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var result CacheResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Compression.IsNull() != tt.expectNull || result.Compression.ValueString() != tt.expectValue {
+				t.Errorf("expected compression null=%v value %q, got %v", tt.expectNull, tt.expectValue, result.Compression)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Update_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "forbidden"}`))
+	}))
+	defer server.Close()
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", true)
+	plan := testCacheStateValue(t, s, "my-cache", false)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+		State: tfsdk.State{Schema: s, Raw: state},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error, got none")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Authentication Error" {
+		t.Errorf("expected 'Authentication Error', got %q", summary)
+	}
+}
+
+func TestCacheResource_Schema_SigningKeyModeValidation(t *testing.T) {
+	_, s := newTestCacheResource(t, "")
+
+	attr, ok := s.Attributes["signing_key_mode"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected 'signing_key_mode' to be a string attribute")
+	}
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{signingKeyModeCachix, true},
+		{signingKeyModeSelf, true},
+		{"Self", false},
+		{"none", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("signing_key_mode"),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() == tt.valid {
+				t.Errorf("value %q: expected valid=%v, got diagnostics %v", tt.value, tt.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSigningKeyModeRequiresReplace(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    types.String
+		expected bool
+	}{
+		{name: "changed on existing cache", state: types.StringValue(signingKeyModeCachix), expected: true},
+		{name: "first set after import", state: types.StringNull(), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				StateValue: tt.state,
+				PlanValue:  types.StringValue(signingKeyModeSelf),
+			}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+
+			signingKeyModeRequiresReplace(context.Background(), req, resp)
+
+			if resp.RequiresReplace != tt.expected {
+				t.Errorf("expected RequiresReplace=%v, got %v", tt.expected, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Create_SigningKeyMode(t *testing.T) {
+	tests := []struct {
+		mode               string
+		generateSigningKey bool
+		keys               []string
+	}{
+		{mode: signingKeyModeCachix, generateSigningKey: true, keys: []string{"my-cache.cachix.org-1:xxxx="}},
+		{mode: signingKeyModeSelf, generateSigningKey: false, keys: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/user":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(User{ID: 1, Username: "testuser"})
+				case r.Method == http.MethodPost && r.URL.Path == "/cache/my-cache":
+					var reqBody CreateCacheRequest
+					if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+						t.Errorf("failed to decode request body: %v", err)
+					}
+					if reqBody.GenerateSigningKey != tt.generateSigningKey {
+						t.Errorf("expected GenerateSigningKey=%v, got %v", tt.generateSigningKey, reqBody.GenerateSigningKey)
+					}
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(Cache{
+						Name:              "my-cache",
+						URI:               "https://my-cache.cachix.org",
+						IsPublic:          true,
+						PublicSigningKeys: tt.keys,
+					})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestCacheResource(t, server.URL)
+			plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"name":                tftypes.NewValue(tftypes.String, "my-cache"),
+				"is_public":           tftypes.NewValue(tftypes.Bool, true),
+				"signing_key_mode":    tftypes.NewValue(tftypes.String, tt.mode),
+				"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"uri":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"public_signing_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+			})
+
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+			r.Create(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var result CacheResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.SigningKeyMode.ValueString() != tt.mode {
+				t.Errorf("expected signing_key_mode %q, got %q", tt.mode, result.SigningKeyMode.ValueString())
+			}
+			if len(result.PublicSigningKeys.Elements()) != len(tt.keys) {
+				t.Errorf("expected %d public signing keys, got %d", len(tt.keys), len(result.PublicSigningKeys.Elements()))
+			}
+		})
+	}
+}
+
+func TestCacheResource_Create_SettingsNotReported(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute string
+		planned   tftypes.Value
+		expected  types.Int64
+	}{
+		{name: "priority configured", attribute: "priority", planned: tftypes.NewValue(tftypes.Number, 30), expected: types.Int64Value(30)},
+		{name: "priority unset", attribute: "priority", planned: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue), expected: types.Int64Null()},
+		{name: "retention_days configured", attribute: "retention_days", planned: tftypes.NewValue(tftypes.Number, 30), expected: types.Int64Value(30)},
+		{name: "retention_days unset", attribute: "retention_days", planned: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue), expected: types.Int64Null()},
+		{name: "max_storage_size_gb configured", attribute: "max_storage_size_gb", planned: tftypes.NewValue(tftypes.Number, 5), expected: types.Int64Value(5)},
+		{name: "max_storage_size_gb unset", attribute: "max_storage_size_gb", planned: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue), expected: types.Int64Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/user":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(User{ID: 1, Username: "testuser"})
+				case r.Method == http.MethodPost && r.URL.Path == "/cache/my-cache":
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+					// The API leaves out the priority and retention settings
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"name": "my-cache", "uri": "https://my-cache.cachix.org", "isPublic": true}`))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestCacheResource(t, server.URL)
+			plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"name":                tftypes.NewValue(tftypes.String, "my-cache"),
+				"is_public":           tftypes.NewValue(tftypes.Bool, true),
+				"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"uri":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"public_signing_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+				tt.attribute:          tt.planned,
+			})
+
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+			r.Create(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var result types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root(tt.attribute), &result)...)
+			if !result.Equal(tt.expected) {
+				t.Errorf("expected %s %s, got %s", tt.attribute, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCacheResource_Read_RetentionNotReported(t *testing.T) {
+	server := newTestCacheServer(t, Cache{Name: "my-cache", URI: "https://my-cache.cachix.org"})
+
+	r, s := newTestCacheResource(t, server.URL)
+	state := testCacheStateValue(t, s, "my-cache", false)
+	state = withTestResourceAttr(t, s, state, "retention_days", tftypes.NewValue(tftypes.Number, 30))
+	state = withTestResourceAttr(t, s, state, "max_storage_size_gb", tftypes.NewValue(tftypes.Number, 5))
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var result CacheResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.RetentionDays.ValueInt64() != 30 || result.MaxStorageSizeGB.ValueInt64() != 5 {
+		t.Errorf("expected retention_days 30 and max_storage_size_gb 5 to be kept, got %s and %s", result.RetentionDays, result.MaxStorageSizeGB)
+	}
+}
+
+func TestCacheResource_Create_AdoptExisting(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		adoptExisting bool
+		expectErr     string
+	}{
+		{
+			name:          "owned by authenticated account",
+			owner:         "testuser",
+			adoptExisting: true,
+		},
+		{
+			name:          "owned by another account",
+			owner:         "someone-else",
+			adoptExisting: true,
+			expectErr:     "Cache Owned by Another Account",
+		},
+		{
+			name:          "owner not reported",
+			adoptExisting: true,
+			expectErr:     "Cache Owner Unknown",
+		},
+		{
+			name:      "adoption disabled",
+			owner:     "testuser",
+			expectErr: "Unable to Create cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestCacheServer(t, Cache{
+				Name:              "my-cache",
+				URI:               "https://my-cache.cachix.org",
+				IsPublic:          false,
+				PublicSigningKeys: []string{"my-cache.cachix.org-1:xxxx="},
+				Owner:             tt.owner,
+			})
+			r, s := newTestCacheResource(t, server.URL)
+			plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"name":                tftypes.NewValue(tftypes.String, "my-cache"),
+				"is_public":           tftypes.NewValue(tftypes.Bool, true),
+				"adopt_existing":      tftypes.NewValue(tftypes.Bool, tt.adoptExisting),
+				"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"uri":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"public_signing_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+			})
+
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+			r.Create(context.Background(), req, resp)
+
+			if tt.expectErr != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.expectErr {
+					t.Errorf("expected error %q, got %q", tt.expectErr, summary)
+				}
+				if len(server.updates) != 0 {
+					t.Errorf("expected no updates, got %v", server.updates)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var result CacheResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.ID.ValueString() != "my-cache" {
+				t.Errorf("expected id my-cache, got %q", result.ID.ValueString())
+			}
+			if !result.IsPublic.ValueBool() {
+				t.Error("expected adopted cache to be updated to public")
+			}
+			if len(server.updates) != 1 {
+				t.Errorf("expected 1 update, got %d", len(server.updates))
+			}
+		})
+	}
 }
 
 func TestCacheResource_Schema_NameValidation(t *testing.T) {
@@ -74,6 +1442,119 @@ func TestCacheResource_Schema_NameValidation(t *testing.T) {
 	}
 }
 
+func TestParseCacheImportID(t *testing.T) {
+	tests := []struct {
+		id          string
+		owner       string
+		name        string
+		expectError bool
+	}{
+		{id: "my-cache", name: "my-cache"},
+		{id: " my-cache ", name: "my-cache"},
+		{id: "https://my-cache.cachix.org", name: "my-cache"},
+		{id: "https://my-cache.cachix.org/", name: "my-cache"},
+		{id: "testuser/my-cache", owner: "testuser", name: "my-cache"},
+		{id: "", expectError: true},
+		{id: "http://my-cache.cachix.org", expectError: true},
+		{id: "https://my-cache.example.com", expectError: true},
+		{id: "https://cachix.org", expectError: true},
+		{id: "https://my-cache.cachix.org/nix-cache-info", expectError: true},
+		{id: "/my-cache", expectError: true},
+		{id: "testuser/", expectError: true},
+		{id: "a/b/c", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			owner, name, err := parseCacheImportID(tt.id)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseCacheImportID(%q) error = %v, expectError %v", tt.id, err, tt.expectError)
+			}
+			if owner != tt.owner || name != tt.name {
+				t.Errorf("parseCacheImportID(%q) = (%q, %q), want (%q, %q)", tt.id, owner, name, tt.owner, tt.name)
+			}
+		})
+	}
+}
+
+func TestCacheResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		expectLookup  bool
+		expectSummary string
+	}{
+		{name: "cache name", id: "my-cache", expectLookup: true},
+		{name: "cache URI", id: "https://my-cache.cachix.org", expectLookup: true},
+		{name: "owner and name", id: "testuser/my-cache", expectLookup: true},
+		{name: "owner mismatch", id: "someone-else/my-cache", expectLookup: true, expectSummary: "Cache Owner Mismatch"},
+		{name: "owner not reported", id: "testuser/ownerless-cache", expectLookup: true, expectSummary: "Cache Owner Unknown"},
+		{name: "missing cache", id: "missing-cache", expectLookup: true, expectSummary: "Cache Not Found"},
+		{name: "invalid name", id: "My_Cache", expectSummary: "Invalid Import ID"},
+		{name: "invalid URI", id: "https://my-cache.example.com", expectSummary: "Invalid Import ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				lookups++
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(Cache{Name: "my-cache", Owner: "testuser"})
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/ownerless-cache":
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(Cache{Name: "ownerless-cache"})
+				case r.Method == http.MethodGet && r.URL.Path == "/cache/missing-cache":
+					w.WriteHeader(http.StatusNotFound)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestCacheResource(t, server.URL)
+			req := resource.ImportStateRequest{ID: tt.id}
+			resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+			r.ImportState(context.Background(), req, resp)
+
+			if (lookups > 0) != tt.expectLookup {
+				t.Errorf("expected cache lookup=%v, got %d requests", tt.expectLookup, lookups)
+			}
+
+			if tt.expectSummary != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.expectSummary {
+					t.Errorf("expected %q, got %q", tt.expectSummary, summary)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var result CacheResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Name.ValueString() != "my-cache" || result.ID.ValueString() != "my-cache" {
+				t.Errorf("expected id and name 'my-cache', got %q and %q", result.ID.ValueString(), result.Name.ValueString())
+			}
+			if result.DeletionPolicy.ValueString() != deletionPolicyDelete {
+				t.Errorf("expected deletion_policy %q, got %q", deletionPolicyDelete, result.DeletionPolicy.ValueString())
+			}
+			if !result.SigningKeyMode.IsNull() {
+				t.Errorf("expected signing_key_mode to be left for the first apply, got %s", result.SigningKeyMode)
+			}
+		})
+	}
+}
+
 // Acceptance Tests
 
 func TestAccCacheResource_Basic(t *testing.T) {
@@ -96,9 +1577,10 @@ func TestAccCacheResource_Basic(t *testing.T) {
 			},
 			// ImportState testing
 			{
-				ResourceName:      "cachix_cache.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "cachix_cache.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"signing_key_mode", "force_destroy"},
 			},
 		},
 	})
@@ -151,6 +1633,36 @@ func TestAccCacheResource_ForceNew(t *testing.T) {
 	})
 }
 
+func TestAccCacheResource_UpdateVisibility(t *testing.T) {
+	cacheName := fmt.Sprintf("test-acc-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum))
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			// Create a public cache
+			{
+				Config: testAccCacheResourceConfig(cacheName, true),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("cachix_cache.test", "is_public", "true"),
+				),
+			},
+			// Making it private should update in place rather than replace
+			{
+				Config: testAccCacheResourceConfig(cacheName, false),
+				ConfigPlanChecks: tfresource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("cachix_cache.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("cachix_cache.test", "is_public", "false"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCacheResource_Import(t *testing.T) {
 	cacheName := fmt.Sprintf("test-acc-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum))
 
@@ -162,12 +1674,22 @@ func TestAccCacheResource_Import(t *testing.T) {
 			{
 				Config: testAccCacheResourceConfig(cacheName, true),
 			},
-			// Import using the cache name
+			// Import using the cache name. The signing key mode is not
+			// reported by the API, so imported state has none
+			{
+				ResourceName:            "cachix_cache.test",
+				ImportState:             true,
+				ImportStateId:           cacheName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"signing_key_mode", "force_destroy"},
+			},
+			// Import using the cache URI
 			{
-				ResourceName:      "cachix_cache.test",
-				ImportState:       true,
-				ImportStateId:     cacheName,
-				ImportStateVerify: true,
+				ResourceName:            "cachix_cache.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("https://%s.cachix.org", cacheName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"signing_key_mode", "force_destroy"},
 			},
 		},
 	})
@@ -176,8 +1698,9 @@ func TestAccCacheResource_Import(t *testing.T) {
 func testAccCacheResourceConfig(name string, isPublic bool) string {
 	return fmt.Sprintf(`
 resource "cachix_cache" "test" {
-  name      = %[1]q
-  is_public = %[2]t
+  name          = %[1]q
+  is_public     = %[2]t
+  force_destroy = true
 }
 `, name, isPublic)
 }
@@ -0,0 +1,277 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &CacheSigningKeyResource{}
+	_ resource.ResourceWithConfigure      = &CacheSigningKeyResource{}
+	_ resource.ResourceWithValidateConfig = &CacheSigningKeyResource{}
+)
+
+// NewCacheSigningKeyResource creates a new cache signing key resource instance.
+func NewCacheSigningKeyResource() resource.Resource {
+	return &CacheSigningKeyResource{}
+}
+
+// CacheSigningKeyResource defines the resource implementation.
+type CacheSigningKeyResource struct {
+	client *CachixClient
+}
+
+// CacheSigningKeyResourceModel describes the resource data model.
+type CacheSigningKeyResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Cache     types.String `tfsdk:"cache"`
+	PublicKey types.String `tfsdk:"public_key"`
+	SecretKey types.String `tfsdk:"secret_key"`
+}
+
+// Metadata returns the resource type name.
+func (r *CacheSigningKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_signing_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *CacheSigningKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Registers a public signing key with a Cachix binary cache.",
+		MarkdownDescription: "Registers an ed25519 public signing key with a Cachix binary cache, typically one created with `signing_key_mode = \"self\"`. Store paths signed with the matching secret key are then trusted by the cache. Replacing the resource rotates the key; destroying it removes the key from the cache.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the signing key, in the form `<cache>/<key name>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the cache to register the key with. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: CacheNameValidators(),
+			},
+			"public_key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ed25519 public key in the Nix format `name:base64`, as written by `nix-store --generate-binary-cache-key`. Changing this forces a new resource to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					nixPublicKeyValidator,
+				},
+			},
+			"secret_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "The matching ed25519 secret key in the Nix format `name:base64`, used only to check that it belongs to `public_key`. It is never sent to Cachix or stored in state. Requires Terraform 1.11 or later.",
+				Validators: []validator.String{
+					nixSecretKeyValidator,
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CacheSigningKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = getClientFromProviderData(req.ProviderData, &resp.Diagnostics, "Resource")
+}
+
+// ValidateConfig checks that the secret key, when given, belongs to the
+// public key.
+func (r *CacheSigningKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CacheSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SecretKey.IsNull() || data.SecretKey.IsUnknown() || data.PublicKey.IsNull() || data.PublicKey.IsUnknown() {
+		return
+	}
+
+	// Malformed keys are reported by the attribute validators
+	publicKey, err := parseNixKey(data.PublicKey.ValueString(), ed25519.PublicKeySize)
+	if err != nil {
+		return
+	}
+	secretKey, err := parseNixKey(data.SecretKey.ValueString(), ed25519.PrivateKeySize)
+	if err != nil {
+		return
+	}
+
+	if err := checkNixKeyPair(publicKey, secretKey); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_key"),
+			"Mismatched Signing Key Pair",
+			fmt.Sprintf("The secret key does not match public_key: %s.", err),
+		)
+	}
+}
+
+// Create registers the signing key with the cache.
+func (r *CacheSigningKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CacheSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	publicKey, err := parseNixKey(data.PublicKey.ValueString(), ed25519.PublicKeySize)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("public_key"), "Invalid Signing Key", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Registering cache signing key", map[string]any{
+		"cache":    data.Cache.ValueString(),
+		"key_name": publicKey.Name,
+	})
+
+	err = r.client.AddCacheSigningKey(ctx, data.Cache.ValueString(), data.PublicKey.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache signing key",
+		ResourceName: publicKey.Name,
+		Operation:    "create",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	data.ID = types.StringValue(data.Cache.ValueString() + "/" + publicKey.Name)
+	data.SecretKey = types.StringNull()
+
+	tflog.Trace(ctx, "Registered cache signing key", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks that the signing key is still registered with the cache.
+func (r *CacheSigningKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CacheSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading cache signing key", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	cache, err := r.client.GetCache(ctx, data.Cache.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache",
+		ResourceName: data.Cache.ValueString(),
+		Operation:    "read",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Cache not found, removing signing key from state", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	if !slices.Contains(cache.PublicSigningKeys, data.PublicKey.ValueString()) {
+		tflog.Warn(ctx, "Signing key no longer registered with cache, removing from state", map[string]any{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource state. The cache and public key force a new
+// resource, so only the write-only secret key can change here and it is not
+// stored.
+func (r *CacheSigningKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CacheSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.SecretKey = types.StringNull()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the signing key from the cache.
+func (r *CacheSigningKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CacheSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Removing cache signing key", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	err := r.client.RemoveCacheSigningKey(ctx, data.Cache.ValueString(), data.PublicKey.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache signing key",
+		ResourceName: data.ID.ValueString(),
+		Operation:    "delete",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Cache signing key already removed", map[string]any{
+				"id": data.ID.ValueString(),
+			})
+		}
+		return
+	}
+
+	tflog.Trace(ctx, "Removed cache signing key", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+}
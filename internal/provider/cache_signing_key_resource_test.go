@@ -0,0 +1,163 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCacheSigningKeyResource_Schema(t *testing.T) {
+	_, s := newTestResource(t, NewCacheSigningKeyResource, "")
+
+	if diags := s.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+
+	attr, ok := s.Attributes["secret_key"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected secret_key to be a string attribute")
+	}
+	if !attr.WriteOnly || !attr.Sensitive {
+		t.Errorf("expected secret_key to be write-only and sensitive, got write-only=%v sensitive=%v", attr.WriteOnly, attr.Sensitive)
+	}
+}
+
+func TestCacheSigningKeyResource_ValidateConfig(t *testing.T) {
+	publicKey, secretKey := testNixKeyPair(t, "my-cache-1", 1)
+	_, otherSecretKey := testNixKeyPair(t, "my-cache-1", 2)
+
+	tests := []struct {
+		name      string
+		secretKey tftypes.Value
+		expectErr bool
+	}{
+		{name: "no secret key", secretKey: tftypes.NewValue(tftypes.String, nil)},
+		{name: "unknown secret key", secretKey: tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+		{name: "matching secret key", secretKey: tftypes.NewValue(tftypes.String, secretKey)},
+		{name: "mismatched secret key", secretKey: tftypes.NewValue(tftypes.String, otherSecretKey), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, s := newTestResource(t, NewCacheSigningKeyResource, "")
+			config := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+				"public_key": tftypes.NewValue(tftypes.String, publicKey),
+				"secret_key": tt.secretKey,
+			})
+
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: config}}
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("expected error=%v, got diagnostics: %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCacheSigningKeyResource_Create(t *testing.T) {
+	publicKey, _ := testNixKeyPair(t, "my-cache-1", 1)
+
+	var registrations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/cache/my-cache/key" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		registrations++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	r, s := newTestResource(t, NewCacheSigningKeyResource, server.URL)
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+		"public_key": tftypes.NewValue(tftypes.String, publicKey),
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if registrations != 1 {
+		t.Errorf("expected 1 registration, got %d", registrations)
+	}
+
+	var result CacheSigningKeyResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.ID.ValueString() != "my-cache/my-cache-1" {
+		t.Errorf("expected id 'my-cache/my-cache-1', got %q", result.ID.ValueString())
+	}
+	if !result.SecretKey.IsNull() {
+		t.Error("expected secret_key to be null in state")
+	}
+}
+
+func TestCacheSigningKeyResource_Read(t *testing.T) {
+	publicKey, _ := testNixKeyPair(t, "my-cache-1", 1)
+	otherPublicKey, _ := testNixKeyPair(t, "my-cache-2", 2)
+
+	tests := []struct {
+		name          string
+		keys          []string
+		cacheDeleted  bool
+		expectRemoved bool
+	}{
+		{name: "registered", keys: []string{otherPublicKey, publicKey}},
+		{name: "removed outside Terraform", keys: []string{otherPublicKey}, expectRemoved: true},
+		{name: "cache deleted", cacheDeleted: true, expectRemoved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/cache/my-cache" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if tt.cacheDeleted {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(Cache{Name: "my-cache", PublicSigningKeys: tt.keys})
+			}))
+			defer server.Close()
+
+			r, s := newTestResource(t, NewCacheSigningKeyResource, server.URL)
+
+			stateValue := newTestResourceValue(t, s, map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, "my-cache/my-cache-1"),
+				"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+				"public_key": tftypes.NewValue(tftypes.String, publicKey),
+			})
+			state := tfsdk.State{Schema: s, Raw: stateValue}
+			resp := &resource.ReadResponse{State: state}
+
+			r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tt.expectRemoved {
+				t.Errorf("expected removed=%v, got state %v", tt.expectRemoved, resp.State.Raw)
+			}
+		})
+	}
+}
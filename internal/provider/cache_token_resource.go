@@ -0,0 +1,280 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &CacheTokenResource{}
+	_ resource.ResourceWithConfigure = &CacheTokenResource{}
+)
+
+const (
+	// cacheTokenPermissionRead allows pulling from a private cache.
+	cacheTokenPermissionRead = "read"
+	// cacheTokenPermissionWrite allows pushing to a cache.
+	cacheTokenPermissionWrite = "write"
+)
+
+// NewCacheTokenResource creates a new cache token resource instance.
+func NewCacheTokenResource() resource.Resource {
+	return &CacheTokenResource{}
+}
+
+// CacheTokenResource defines the resource implementation.
+type CacheTokenResource struct {
+	client *CachixClient
+}
+
+// CacheTokenResourceModel describes the resource data model.
+type CacheTokenResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Cache      types.String `tfsdk:"cache"`
+	Permission types.String `tfsdk:"permission"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+	Token      types.String `tfsdk:"token"`
+	NetrcLine  types.String `tfsdk:"netrc_line"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *CacheTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_token"
+}
+
+// Schema defines the schema for the resource.
+func (r *CacheTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manages an auth token scoped to a Cachix binary cache.",
+		MarkdownDescription: "Manages an auth token scoped to a Cachix binary cache, e.g. a read token for consumers of a private cache or a write token for CI pushing to it. The secret is only returned when the token is created. Destroying the resource revokes the token.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the cache the token gives access to. Changing this forces a new token to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: CacheNameValidators(),
+			},
+			"permission": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The access the token grants: `read` to pull from the cache or `write` to also push to it. Changing this forces a new token to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(cacheTokenPermissionRead, cacheTokenPermissionWrite),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "When the token expires, as an RFC 3339 timestamp such as `2030-01-01T00:00:00Z`. The token does not expire when unset. Changing this forces a new token to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the token. Only known after the token is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"netrc_line": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "A netrc line authenticating Nix to `<cache>.cachix.org` with the token, for use in the file set by the `netrc-file` option of `nix.conf`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token was created, as an RFC 3339 timestamp.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CacheTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = getClientFromProviderData(req.ProviderData, &resp.Diagnostics, "Resource")
+}
+
+// Create creates a new cache token.
+func (r *CacheTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CacheTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating cache token", map[string]any{
+		"cache":      data.Cache.ValueString(),
+		"permission": data.Permission.ValueString(),
+		"expires_at": data.ExpiresAt.ValueString(),
+	})
+
+	token, err := r.client.CreateCacheToken(ctx, data.Cache.ValueString(), CreateCacheTokenRequest{
+		Permission: data.Permission.ValueString(),
+		ExpiresAt:  data.ExpiresAt.ValueString(),
+	})
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache token",
+		ResourceName: data.Cache.ValueString(),
+		Operation:    "create",
+	}
+	if errorHandler.Handle(err) {
+		return
+	}
+
+	data.ID = types.StringValue(token.ID)
+	data.Token = types.StringValue(token.Secret)
+	data.NetrcLine = types.StringValue(cacheNetrcLine(data.Cache.ValueString(), token.Secret))
+	data.CreatedAt = rfc3339Value(token.CreatedAt, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Created cache token", map[string]any{
+		"cache": data.Cache.ValueString(),
+		"id":    data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the permission of the cache token, removing the token from
+// state if it was revoked or its cache was deleted.
+func (r *CacheTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CacheTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading cache token", map[string]any{
+		"cache": data.Cache.ValueString(),
+		"id":    data.ID.ValueString(),
+	})
+
+	token, err := r.client.GetCacheToken(ctx, data.Cache.ValueString(), data.ID.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache token",
+		ResourceName: data.Cache.ValueString(),
+		Operation:    "read",
+	}
+	if errorHandler.HandleTokenRead(ctx, err, token != nil && token.Revoked, &resp.State, map[string]any{
+		"cache": data.Cache.ValueString(),
+		"id":    data.ID.ValueString(),
+	}) {
+		return
+	}
+
+	// A permission changed outside Terraform shows up as drift and replaces
+	// the token. The token and netrc_line keep the secret from creation.
+	data.Permission = types.StringValue(token.Permission)
+	if token.CreatedAt != "" {
+		data.CreatedAt = rfc3339Value(token.CreatedAt, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only copies the plan into state. The cache, permission and expiry
+// all replace the token, which cannot be changed once issued.
+func (r *CacheTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CacheTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete revokes the cache token.
+func (r *CacheTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CacheTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !requireClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking cache token", map[string]any{
+		"cache": data.Cache.ValueString(),
+		"id":    data.ID.ValueString(),
+	})
+
+	err := r.client.RevokeCacheToken(ctx, data.Cache.ValueString(), data.ID.ValueString())
+	errorHandler := &APIErrorHandler{
+		Diagnostics:  &resp.Diagnostics,
+		ResourceType: "cache token",
+		ResourceName: data.Cache.ValueString(),
+		Operation:    "revoke",
+	}
+	if shouldReturn, wasNotFound := errorHandler.HandleNotFoundAsRemoved(err); shouldReturn {
+		if wasNotFound {
+			tflog.Warn(ctx, "Cache token already revoked", map[string]any{
+				"cache": data.Cache.ValueString(),
+				"id":    data.ID.ValueString(),
+			})
+		}
+		return
+	}
+
+	tflog.Trace(ctx, "Revoked cache token", map[string]any{
+		"cache": data.Cache.ValueString(),
+		"id":    data.ID.ValueString(),
+	})
+}
+
+// cacheNetrcLine returns the netrc line authenticating Nix to the cache with
+// the given token.
+func cacheNetrcLine(cacheName, token string) string {
+	return fmt.Sprintf("machine %s.cachix.org password %s", cacheName, token)
+}
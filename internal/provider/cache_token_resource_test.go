@@ -0,0 +1,183 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCacheTokenResource_Schema_SecretsSensitive(t *testing.T) {
+	_, s := newTestResource(t, NewCacheTokenResource, "")
+
+	for _, name := range []string{"token", "netrc_line"} {
+		attr, ok := s.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("expected %s to be a string attribute", name)
+		}
+		if !attr.Sensitive {
+			t.Errorf("expected %s to be sensitive", name)
+		}
+	}
+}
+
+func TestCacheTokenResource_Schema_PermissionValidation(t *testing.T) {
+	tests := []struct {
+		value     string
+		expectErr bool
+	}{
+		{value: "read"},
+		{value: "write"},
+		{value: "admin", expectErr: true},
+	}
+
+	_, s := newTestResource(t, NewCacheTokenResource, "")
+	attr := s.Attributes["permission"].(schema.StringAttribute)
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("permission"),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("expected error=%v, got diagnostics: %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCacheTokenResource_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/cache/my-cache/token" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(CacheToken{ID: "token-1", Permission: "write", Secret: "secret-value"})
+	}))
+	defer server.Close()
+
+	r, s := newTestResource(t, NewCacheTokenResource, server.URL)
+	plan := newTestResourceValue(t, s, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+		"permission": tftypes.NewValue(tftypes.String, "write"),
+		"token":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"netrc_line": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"created_at": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, nil)}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var result CacheTokenResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+	if result.Token.ValueString() != "secret-value" {
+		t.Errorf("expected token 'secret-value', got %q", result.Token.ValueString())
+	}
+	if expected := "machine my-cache.cachix.org password secret-value"; result.NetrcLine.ValueString() != expected {
+		t.Errorf("expected netrc_line %q, got %q", expected, result.NetrcLine.ValueString())
+	}
+	if !result.CreatedAt.IsNull() {
+		t.Errorf("expected null created_at, got %q", result.CreatedAt.ValueString())
+	}
+}
+
+func TestCacheTokenResource_Read(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		token         CacheToken
+		expectRemoved bool
+	}{
+		{
+			name:   "active",
+			status: http.StatusOK,
+			token:  CacheToken{ID: "token-1", Permission: "write"},
+		},
+		{
+			name:          "revoked",
+			status:        http.StatusOK,
+			token:         CacheToken{ID: "token-1", Permission: "read", Revoked: true},
+			expectRemoved: true,
+		},
+		{
+			name:          "deleted",
+			status:        http.StatusNotFound,
+			expectRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/cache/my-cache/token/token-1" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(tt.token)
+				}
+			}))
+			defer server.Close()
+
+			r, s := newTestResource(t, NewCacheTokenResource, server.URL)
+			state := tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, "token-1"),
+				"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+				"permission": tftypes.NewValue(tftypes.String, "read"),
+				"token":      tftypes.NewValue(tftypes.String, "secret-value"),
+				"netrc_line": tftypes.NewValue(tftypes.String, "machine my-cache.cachix.org password secret-value"),
+			})}
+			resp := &resource.ReadResponse{State: state}
+
+			r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tt.expectRemoved {
+				t.Fatalf("expected removed=%v, got state %v", tt.expectRemoved, resp.State.Raw)
+			}
+			if tt.expectRemoved {
+				return
+			}
+
+			var result CacheTokenResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &result)...)
+			if result.Permission.ValueString() != "write" {
+				t.Errorf("expected permission changed outside Terraform to be refreshed, got %q", result.Permission.ValueString())
+			}
+			if expected := "machine my-cache.cachix.org password secret-value"; result.NetrcLine.ValueString() != expected {
+				t.Errorf("expected netrc_line %q to be kept, got %q", expected, result.NetrcLine.ValueString())
+			}
+		})
+	}
+}
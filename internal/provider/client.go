@@ -6,42 +6,120 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
+	// DefaultAPIHost is the base URL of the public Cachix API
+	DefaultAPIHost = "https://app.cachix.org/api/v1"
 	// DefaultRetryMax is the maximum number of retries for transient errors
 	DefaultRetryMax = 3
 	// DefaultRetryWaitMin is the minimum wait time between retries
 	DefaultRetryWaitMin = 1 * time.Second
 	// DefaultRetryWaitMax is the maximum wait time between retries
 	DefaultRetryWaitMax = 30 * time.Second
+	// DefaultRequestTimeout is the timeout for a single HTTP request
+	DefaultRequestTimeout = 30 * time.Second
 )
 
+const (
+	// waitSourceBackoff identifies retry delays computed by the retry policy.
+	waitSourceBackoff = "retry policy backoff"
+	// unixTimestampThreshold separates rate-limit reset values given as Unix
+	// timestamps from values given as a number of seconds.
+	unixTimestampThreshold = 1_000_000_000
+)
+
+// rateLimitResetHeaders lists the rate-limit reset headers consulted when no
+// Retry-After header is present, in order of preference.
+var rateLimitResetHeaders = []string{
+	"RateLimit-Reset",
+	"X-RateLimit-Reset",
+	"X-Rate-Limit-Reset",
+}
+
 // CachixClient is the HTTP client for interacting with the Cachix API.
 type CachixClient struct {
-	baseURL    string
-	authToken  string
-	httpClient *http.Client
-	userAgent  string
-	retryMax   int
+	baseURL      string
+	authToken    string
+	httpClient   *http.Client
+	userAgent    string
+	retryMax     int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+	retryPolicy  RetryPolicy
+	tlsConfig    *tls.Config
+	proxy        func(*http.Request) (*url.URL, error)
+
+	// requestTimeout bounds a single HTTP attempt. The deadline of the whole
+	// operation, including retries and the waits between them, comes from the
+	// caller's context.
+	requestTimeout time.Duration
+}
+
+// ClientOption configures optional settings of a CachixClient.
+type ClientOption func(*CachixClient)
+
+// WithRetryMax sets the maximum number of retries for transient errors.
+func WithRetryMax(retryMax int) ClientOption {
+	return func(c *CachixClient) {
+		c.retryMax = retryMax
+	}
 }
 
-// Cache represents a Cachix binary cache.
+// WithRetryWait sets the minimum and maximum wait time between retries.
+func WithRetryWait(waitMin, waitMax time.Duration) ClientOption {
+	return func(c *CachixClient) {
+		c.retryWaitMin = waitMin
+		c.retryWaitMax = waitMax
+	}
+}
+
+// WithRequestTimeout sets the timeout for a single HTTP request. A zero
+// timeout disables it, leaving only the deadline of the request context.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *CachixClient) {
+		c.requestTimeout = timeout
+	}
+}
+
+// Cache represents a Cachix binary cache, as described by the BinaryCache
+// schema of the Cachix API (https://app.cachix.org/api/v1/). The owner of the
+// cache is reported as the GitHub username of its account.
 type Cache struct {
 	Name              string   `json:"name"`
 	URI               string   `json:"uri"`
 	IsPublic          bool     `json:"isPublic"`
 	PublicSigningKeys []string `json:"publicSigningKeys"`
+	Priority          *int64   `json:"priority,omitempty"`
+	RetentionDays     *int64   `json:"retentionDays,omitempty"`
+	MaxStorageSizeGB  *int64   `json:"maxStorageSizeGB,omitempty"`
+	Compression       *string  `json:"compression,omitempty"`
 	CreatedAt         string   `json:"createdAt,omitempty"`
+	Owner             string   `json:"githubUsername,omitempty"`
+	StorePathCount    *int64   `json:"storePathCount,omitempty"`
+	StorageBytes      *int64   `json:"storageBytes,omitempty"`
+}
+
+// IsEmpty reports whether the cache holds no store paths. known is false when
+// the API did not report the usage of the cache, in which case the cache must
+// not be assumed to be empty.
+func (c *Cache) IsEmpty() (empty, known bool) {
+	if c.StorePathCount == nil || c.StorageBytes == nil {
+		return false, false
+	}
+	return *c.StorePathCount == 0 && *c.StorageBytes == 0, true
 }
 
 // User represents a Cachix user.
@@ -55,9 +133,30 @@ type User struct {
 
 // CreateCacheRequest represents the request body for creating a cache.
 type CreateCacheRequest struct {
-	IsPublic           bool `json:"isPublic"`
-	GenerateSigningKey bool `json:"generateSigningKey"`
-	AccountID          int  `json:"accountID"`
+	IsPublic           bool    `json:"isPublic"`
+	GenerateSigningKey bool    `json:"generateSigningKey"`
+	AccountID          int     `json:"accountID"`
+	Priority           *int64  `json:"priority,omitempty"`
+	RetentionDays      *int64  `json:"retentionDays,omitempty"`
+	MaxStorageSizeGB   *int64  `json:"maxStorageSizeGB,omitempty"`
+	Compression        *string `json:"compression,omitempty"`
+}
+
+// UpdateCacheRequest represents the request body for updating cache settings.
+// Only the settings that are set are changed. An empty Compression resets the
+// compression method to the Cachix default.
+type UpdateCacheRequest struct {
+	IsPublic         *bool   `json:"isPublic,omitempty"`
+	Priority         *int64  `json:"priority,omitempty"`
+	RetentionDays    *int64  `json:"retentionDays,omitempty"`
+	MaxStorageSizeGB *int64  `json:"maxStorageSizeGB,omitempty"`
+	Compression      *string `json:"compression,omitempty"`
+}
+
+// IsEmpty reports whether the request changes no settings.
+func (r UpdateCacheRequest) IsEmpty() bool {
+	return r.IsPublic == nil && r.Priority == nil && r.RetentionDays == nil && r.MaxStorageSizeGB == nil &&
+		r.Compression == nil
 }
 
 // APIError represents an error response from the Cachix API.
@@ -74,17 +173,69 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("cachix API error (status %d): %s", e.StatusCode, e.Body)
 }
 
+// WithRetryPolicy sets the policy deciding which requests are retried and how
+// long to wait between attempts. Defaults to FullJitterBackoff using the
+// configured retry wait bounds.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *CachixClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for connections to the API,
+// e.g. to trust a custom certificate authority or present a client certificate.
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(c *CachixClient) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// WithProxy sets the function selecting the proxy for each request, overriding
+// the proxy environment variables.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(c *CachixClient) {
+		c.proxy = proxy
+	}
+}
+
 // NewCachixClient creates a new Cachix API client.
-func NewCachixClient(baseURL, authToken, version string) *CachixClient {
-	return &CachixClient{
-		baseURL:   baseURL,
-		authToken: authToken,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		userAgent: fmt.Sprintf("terraform-provider-cachix/%s", version),
-		retryMax:  DefaultRetryMax,
+func NewCachixClient(baseURL, authToken, version string, opts ...ClientOption) *CachixClient {
+	c := &CachixClient{
+		baseURL:        baseURL,
+		authToken:      authToken,
+		httpClient:     &http.Client{},
+		userAgent:      fmt.Sprintf("terraform-provider-cachix/%s", version),
+		retryMax:       DefaultRetryMax,
+		retryWaitMin:   DefaultRetryWaitMin,
+		retryWaitMax:   DefaultRetryWaitMax,
+		requestTimeout: DefaultRequestTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.httpClient.Timeout = c.requestTimeout
+
+	if c.tlsConfig != nil || c.proxy != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if c.tlsConfig != nil {
+			transport.TLSClientConfig = c.tlsConfig
+		}
+		if c.proxy != nil {
+			transport.Proxy = c.proxy
+		}
+		c.httpClient.Transport = transport
 	}
+
+	if c.retryPolicy == nil {
+		c.retryPolicy = &FullJitterBackoff{
+			WaitMin: c.retryWaitMin,
+			WaitMax: c.retryWaitMax,
+		}
+	}
+
+	return c
 }
 
 // doRequest performs an HTTP request with retry logic for transient errors.
@@ -100,19 +251,35 @@ func (c *CachixClient) doRequest(ctx context.Context, method, path string, body
 
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
-	var lastErr error
+	var (
+		lastErr          error
+		lastWait         time.Duration
+		serverWait       time.Duration
+		serverWaitSource string
+	)
 	for attempt := 0; attempt <= c.retryMax; attempt++ {
 		if attempt > 0 {
-			// Calculate exponential backoff
-			wait := c.calculateBackoff(attempt)
+			// Prefer the delay requested by the server, otherwise ask the retry policy
+			wait, waitSource := c.retryPolicy.Backoff(attempt, lastWait), waitSourceBackoff
+			if serverWaitSource != "" {
+				wait, waitSource = serverWait, serverWaitSource
+			}
+			lastWait = wait
+
+			// Give up early rather than sleeping past the operation deadline
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return nil, nil, fmt.Errorf("operation deadline exceeded before retry %d: %w (last error: %v)", attempt, context.DeadlineExceeded, lastErr)
+			}
+
 			tflog.Debug(ctx, "Retrying request after transient error", map[string]any{
-				"attempt": attempt,
-				"wait":    wait.String(),
-				"url":     url,
+				"attempt":     attempt,
+				"wait":        wait.String(),
+				"wait_source": waitSource,
+				"url":         url,
 			})
 			select {
 			case <-ctx.Done():
-				return nil, nil, ctx.Err()
+				return nil, nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
 			case <-time.After(wait):
 			}
 
@@ -139,8 +306,13 @@ func (c *CachixClient) doRequest(ctx context.Context, method, path string, body
 			"attempt": attempt,
 		})
 
+		serverWait, serverWaitSource = 0, ""
+
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil || !c.retryPolicy.ShouldRetry(0, err) {
+				return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+			}
 			lastErr = fmt.Errorf("failed to execute request: %w", err)
 			continue
 		}
@@ -153,11 +325,12 @@ func (c *CachixClient) doRequest(ctx context.Context, method, path string, body
 		}
 
 		// Check if we should retry based on status code
-		if c.shouldRetry(resp.StatusCode) {
+		if c.retryPolicy.ShouldRetry(resp.StatusCode, nil) {
 			lastErr = &APIError{
 				StatusCode: resp.StatusCode,
 				Body:       string(respBody),
 			}
+			serverWait, serverWaitSource = c.retryAfter(resp.Header, time.Now())
 			continue
 		}
 
@@ -167,20 +340,83 @@ func (c *CachixClient) doRequest(ctx context.Context, method, path string, body
 	return nil, nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// shouldRetry determines if a request should be retried based on status code.
-func (c *CachixClient) shouldRetry(statusCode int) bool {
-	// Retry on server errors (5xx) and rate limiting (429)
-	return statusCode >= 500 || statusCode == 429
+// retryAfter returns the delay requested by the server through the Retry-After
+// header or a rate-limit reset header, capped at the configured maximum wait,
+// along with the name of the header that set it. The returned source is empty
+// if no usable header was present.
+func (c *CachixClient) retryAfter(header http.Header, now time.Time) (time.Duration, string) {
+	source := "Retry-After"
+	wait, ok := parseRetryAfter(header.Get("Retry-After"), now)
+	if !ok {
+		for _, name := range rateLimitResetHeaders {
+			if wait, ok = parseRateLimitReset(header.Get(name), now); ok {
+				source = name
+				break
+			}
+		}
+	}
+	if !ok {
+		return 0, ""
+	}
+
+	if wait > c.retryWaitMax {
+		wait = c.retryWaitMax
+		source += " (capped)"
+	}
+
+	return wait, source
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as a number
+// of seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return nonNegative(t.Sub(now)), true
+	}
+
+	return 0, false
 }
 
-// calculateBackoff calculates the backoff duration for a retry attempt.
-func (c *CachixClient) calculateBackoff(attempt int) time.Duration {
-	// Exponential backoff: min * 2^attempt, capped at max
-	wait := float64(DefaultRetryWaitMin) * math.Pow(2, float64(attempt-1))
-	if wait > float64(DefaultRetryWaitMax) {
-		wait = float64(DefaultRetryWaitMax)
+// parseRateLimitReset parses a rate-limit reset header value. Values that look
+// like a Unix timestamp are treated as an absolute reset time, smaller values
+// as a number of seconds to wait.
+func parseRateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
 	}
-	return time.Duration(wait)
+
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	if seconds >= unixTimestampThreshold {
+		reset := time.Unix(0, int64(seconds*float64(time.Second)))
+		return nonNegative(reset.Sub(now)), true
+	}
+
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// nonNegative clamps negative durations to zero.
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
 }
 
 // handleErrorResponse converts an HTTP response to an appropriate error.
@@ -240,21 +476,59 @@ func (c *CachixClient) GetCache(ctx context.Context, name string) (*Cache, error
 	}
 
 	tflog.Debug(ctx, "Got cache", map[string]any{
-		"name":      cache.Name,
-		"uri":       cache.URI,
-		"is_public": cache.IsPublic,
+		"name":        cache.Name,
+		"uri":         cache.URI,
+		"is_public":   cache.IsPublic,
+		"priority":    cache.Priority,
+		"compression": cache.Compression,
 	})
 
 	return &cache, nil
 }
 
-// CreateCache creates a new cache with the given name and visibility.
-func (c *CachixClient) CreateCache(ctx context.Context, name string, isPublic bool) (*Cache, error) {
-	tflog.Debug(ctx, "Creating cache", map[string]any{
-		"name":      name,
-		"is_public": isPublic,
-	})
+// CreateCacheOption configures optional settings of a cache being created.
+type CreateCacheOption func(*CreateCacheRequest)
+
+// WithSelfManagedSigningKey creates the cache without a Cachix-managed signing
+// key, for caches whose store paths are signed with keys held by the owner.
+func WithSelfManagedSigningKey() CreateCacheOption {
+	return func(r *CreateCacheRequest) {
+		r.GenerateSigningKey = false
+	}
+}
+
+// WithPriority sets the substituter priority advertised by the cache.
+func WithPriority(priority int64) CreateCacheOption {
+	return func(r *CreateCacheRequest) {
+		r.Priority = &priority
+	}
+}
+
+// WithRetention sets how many days store paths are kept and the maximum
+// storage size in GB before the oldest store paths are garbage collected.
+// A zero value leaves the respective setting at its default.
+func WithRetention(retentionDays, maxStorageSizeGB int64) CreateCacheOption {
+	return func(r *CreateCacheRequest) {
+		if retentionDays > 0 {
+			r.RetentionDays = &retentionDays
+		}
+		if maxStorageSizeGB > 0 {
+			r.MaxStorageSizeGB = &maxStorageSizeGB
+		}
+	}
+}
+
+// WithCompression sets the compression method used for NARs pushed to the
+// cache, e.g. "xz" or "zstd".
+func WithCompression(compression string) CreateCacheOption {
+	return func(r *CreateCacheRequest) {
+		r.Compression = &compression
+	}
+}
 
+// CreateCache creates a new cache with the given name and visibility. By
+// default Cachix generates and manages the signing key of the cache.
+func (c *CachixClient) CreateCache(ctx context.Context, name string, isPublic bool, opts ...CreateCacheOption) (*Cache, error) {
 	// First, get the current user to obtain the accountID
 	user, err := c.GetUser(ctx)
 	if err != nil {
@@ -266,6 +540,15 @@ func (c *CachixClient) CreateCache(ctx context.Context, name string, isPublic bo
 		GenerateSigningKey: true,
 		AccountID:          user.ID,
 	}
+	for _, opt := range opts {
+		opt(&reqBody)
+	}
+
+	tflog.Debug(ctx, "Creating cache", map[string]any{
+		"name":                 name,
+		"is_public":            isPublic,
+		"generate_signing_key": reqBody.GenerateSigningKey,
+	})
 
 	resp, body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/cache/%s", name), reqBody)
 	if err != nil {
@@ -291,6 +574,35 @@ func (c *CachixClient) CreateCache(ctx context.Context, name string, isPublic bo
 	return cache, nil
 }
 
+// UpdateCache changes the settings of an existing cache and returns the
+// updated cache.
+func (c *CachixClient) UpdateCache(ctx context.Context, name string, update UpdateCacheRequest) (*Cache, error) {
+	tflog.Debug(ctx, "Updating cache", map[string]any{"name": name})
+
+	resp, body, err := c.doRequest(ctx, http.MethodPatch, fmt.Sprintf("/cache/%s", name), update)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	// The API returns an empty body on success, so fetch the cache details
+	cache, err := c.GetCache(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cache updated but failed to fetch details: %w", err)
+	}
+
+	tflog.Info(ctx, "Updated cache", map[string]any{
+		"name":      cache.Name,
+		"is_public": cache.IsPublic,
+		"priority":  cache.Priority,
+	})
+
+	return cache, nil
+}
+
 // DeleteCache deletes a cache by name.
 func (c *CachixClient) DeleteCache(ctx context.Context, name string) error {
 	tflog.Debug(ctx, "Deleting cache", map[string]any{"name": name})
@@ -318,6 +630,16 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsConflictError checks if an error is a 409 Conflict error, e.g. when
+// creating a cache whose name is already taken.
+func IsConflictError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
 // GetUser retrieves the current authenticated user.
 func (c *CachixClient) GetUser(ctx context.Context) (*User, error) {
 	tflog.Debug(ctx, "Getting current user")
@@ -342,3 +664,378 @@ func (c *CachixClient) GetUser(ctx context.Context) (*User, error) {
 
 	return &user, nil
 }
+
+// AuthToken represents a Cachix personal auth token.
+type AuthToken struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
+	Revoked     bool   `json:"revoked,omitempty"`
+	// Secret is only returned when the token is created.
+	Secret string `json:"token,omitempty"`
+}
+
+// CreateAuthTokenRequest represents the request body for creating a personal
+// auth token. ExpiresAt is an RFC 3339 timestamp; an empty value creates a
+// token that does not expire.
+type CreateAuthTokenRequest struct {
+	Description string `json:"description"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
+}
+
+// CreateAuthToken creates a personal auth token for the authenticated user
+// with POST /token, as documented in the Cachix API reference
+// (https://app.cachix.org/api/v1/). The returned token holds the secret, which
+// the API does not return again. A token created without a secret in the
+// response is revoked, as it could neither be used nor tracked.
+func (c *CachixClient) CreateAuthToken(ctx context.Context, req CreateAuthTokenRequest) (*AuthToken, error) {
+	tflog.Debug(ctx, "Creating auth token", map[string]any{
+		"description": req.Description,
+		"expires_at":  req.ExpiresAt,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodPost, "/token", req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	var token AuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth token response: %w", err)
+	}
+	if token.ID == "" {
+		return nil, errors.New("auth token response is missing the token id; a token may have been created and must be revoked manually")
+	}
+	if token.Secret == "" {
+		if err := c.RevokeAuthToken(ctx, token.ID); err != nil {
+			return nil, fmt.Errorf("auth token response is missing the secret and revoking token %s failed, revoke it manually: %w", token.ID, err)
+		}
+		return nil, fmt.Errorf("auth token response is missing the secret, token %s was revoked", token.ID)
+	}
+
+	tflog.Info(ctx, "Created auth token", map[string]any{"id": token.ID})
+
+	return &token, nil
+}
+
+// GetAuthToken retrieves a personal auth token by ID with GET /token/{id}.
+// The secret is not included.
+func (c *CachixClient) GetAuthToken(ctx context.Context, id string) (*AuthToken, error) {
+	tflog.Debug(ctx, "Getting auth token", map[string]any{"id": id})
+
+	resp, body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/token/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	var token AuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth token response: %w", err)
+	}
+
+	tflog.Debug(ctx, "Got auth token", map[string]any{
+		"id":      token.ID,
+		"revoked": token.Revoked,
+	})
+
+	return &token, nil
+}
+
+// RevokeAuthToken revokes a personal auth token by ID with DELETE /token/{id}.
+func (c *CachixClient) RevokeAuthToken(ctx context.Context, id string) error {
+	tflog.Debug(ctx, "Revoking auth token", map[string]any{"id": id})
+
+	resp, body, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/token/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Revoked auth token", map[string]any{"id": id})
+
+	return nil
+}
+
+// CacheToken represents an auth token scoped to a single cache.
+type CacheToken struct {
+	ID         string `json:"id"`
+	Permission string `json:"permission"`
+	CreatedAt  string `json:"createdAt,omitempty"`
+	ExpiresAt  string `json:"expiresAt,omitempty"`
+	Revoked    bool   `json:"revoked,omitempty"`
+	// Secret is only returned when the token is created.
+	Secret string `json:"token,omitempty"`
+}
+
+// CreateCacheTokenRequest represents the request body for creating a cache
+// token. Permission is "read" or "write"; ExpiresAt is an RFC 3339 timestamp
+// and an empty value creates a token that does not expire.
+type CreateCacheTokenRequest struct {
+	Permission string `json:"permission"`
+	ExpiresAt  string `json:"expiresAt,omitempty"`
+}
+
+// CreateCacheToken creates an auth token for the given cache with
+// POST /cache/{name}/token, as documented in the Cachix API reference
+// (https://app.cachix.org/api/v1/). The returned token holds the secret, which
+// the API does not return again. As with CreateAuthToken, a token created
+// without a secret in the response is revoked.
+func (c *CachixClient) CreateCacheToken(ctx context.Context, cacheName string, req CreateCacheTokenRequest) (*CacheToken, error) {
+	tflog.Debug(ctx, "Creating cache token", map[string]any{
+		"cache":      cacheName,
+		"permission": req.Permission,
+		"expires_at": req.ExpiresAt,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/cache/%s/token", cacheName), req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	var token CacheToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache token response: %w", err)
+	}
+	if token.ID == "" {
+		return nil, errors.New("cache token response is missing the token id; a token may have been created and must be revoked manually")
+	}
+	if token.Secret == "" {
+		if err := c.RevokeCacheToken(ctx, cacheName, token.ID); err != nil {
+			return nil, fmt.Errorf("cache token response is missing the secret and revoking token %s failed, revoke it manually: %w", token.ID, err)
+		}
+		return nil, fmt.Errorf("cache token response is missing the secret, token %s was revoked", token.ID)
+	}
+
+	tflog.Info(ctx, "Created cache token", map[string]any{
+		"cache": cacheName,
+		"id":    token.ID,
+	})
+
+	return &token, nil
+}
+
+// GetCacheToken retrieves an auth token of the given cache by ID with
+// GET /cache/{name}/token/{id}. The secret is not included.
+func (c *CachixClient) GetCacheToken(ctx context.Context, cacheName, id string) (*CacheToken, error) {
+	tflog.Debug(ctx, "Getting cache token", map[string]any{
+		"cache": cacheName,
+		"id":    id,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/cache/%s/token/%s", cacheName, url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	var token CacheToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache token response: %w", err)
+	}
+
+	tflog.Debug(ctx, "Got cache token", map[string]any{
+		"cache":   cacheName,
+		"id":      token.ID,
+		"revoked": token.Revoked,
+	})
+
+	return &token, nil
+}
+
+// RevokeCacheToken revokes an auth token of the given cache by ID with
+// DELETE /cache/{name}/token/{id}.
+func (c *CachixClient) RevokeCacheToken(ctx context.Context, cacheName, id string) error {
+	tflog.Debug(ctx, "Revoking cache token", map[string]any{
+		"cache": cacheName,
+		"id":    id,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/cache/%s/token/%s", cacheName, url.PathEscape(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Revoked cache token", map[string]any{
+		"cache": cacheName,
+		"id":    id,
+	})
+
+	return nil
+}
+
+// addCacheSigningKeyRequest represents the request body for registering a
+// public signing key with a cache.
+type addCacheSigningKeyRequest struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// AddCacheSigningKey registers a Nix-format public signing key
+// (name:base64) with a cache, so that store paths signed with the matching
+// secret key are trusted.
+func (c *CachixClient) AddCacheSigningKey(ctx context.Context, cacheName, publicKey string) error {
+	tflog.Debug(ctx, "Adding cache signing key", map[string]any{
+		"cache":      cacheName,
+		"public_key": publicKey,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/cache/%s/key", cacheName),
+		addCacheSigningKeyRequest{PublicKey: publicKey})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Added cache signing key", map[string]any{"cache": cacheName})
+
+	return nil
+}
+
+// RemoveCacheSigningKey removes a public signing key from a cache.
+func (c *CachixClient) RemoveCacheSigningKey(ctx context.Context, cacheName, publicKey string) error {
+	tflog.Debug(ctx, "Removing cache signing key", map[string]any{
+		"cache":      cacheName,
+		"public_key": publicKey,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodDelete,
+		fmt.Sprintf("/cache/%s/key/%s", cacheName, url.PathEscape(publicKey)), nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Removed cache signing key", map[string]any{"cache": cacheName})
+
+	return nil
+}
+
+// CachePermission represents the access of a GitHub user or team to a cache.
+// Exactly one of Username and Team is set; Team is "org/team-slug".
+type CachePermission struct {
+	Username   string `json:"githubUsername,omitempty"`
+	Team       string `json:"githubTeam,omitempty"`
+	Permission string `json:"permission"`
+}
+
+// Subject returns the GitHub user or team the permission is granted to.
+func (p CachePermission) Subject() string {
+	if p.Team != "" {
+		return p.Team
+	}
+	return p.Username
+}
+
+// path returns the API path of the permission within the cache.
+func (p CachePermission) path(cacheName string) string {
+	if p.Team != "" {
+		return fmt.Sprintf("/cache/%s/permissions/team/%s", cacheName, url.PathEscape(p.Team))
+	}
+	return fmt.Sprintf("/cache/%s/permissions/user/%s", cacheName, url.PathEscape(p.Username))
+}
+
+// ListCachePermissions retrieves the users and teams with access to a cache.
+func (c *CachixClient) ListCachePermissions(ctx context.Context, cacheName string) ([]CachePermission, error) {
+	tflog.Debug(ctx, "Listing cache permissions", map[string]any{"cache": cacheName})
+
+	resp, body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/cache/%s/permissions", cacheName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	var permissions []CachePermission
+	if err := json.Unmarshal(body, &permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache permissions response: %w", err)
+	}
+
+	tflog.Debug(ctx, "Listed cache permissions", map[string]any{
+		"cache": cacheName,
+		"count": len(permissions),
+	})
+
+	return permissions, nil
+}
+
+// SetCachePermission grants a GitHub user or team access to a cache,
+// replacing any access they already have.
+func (c *CachixClient) SetCachePermission(ctx context.Context, cacheName string, permission CachePermission) error {
+	tflog.Debug(ctx, "Setting cache permission", map[string]any{
+		"cache":      cacheName,
+		"subject":    permission.Subject(),
+		"permission": permission.Permission,
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodPut, permission.path(cacheName),
+		map[string]string{"permission": permission.Permission})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Set cache permission", map[string]any{
+		"cache":   cacheName,
+		"subject": permission.Subject(),
+	})
+
+	return nil
+}
+
+// RemoveCachePermission revokes the access of a GitHub user or team to a
+// cache.
+func (c *CachixClient) RemoveCachePermission(ctx context.Context, cacheName string, permission CachePermission) error {
+	tflog.Debug(ctx, "Removing cache permission", map[string]any{
+		"cache":   cacheName,
+		"subject": permission.Subject(),
+	})
+
+	resp, body, err := c.doRequest(ctx, http.MethodDelete, permission.path(cacheName), nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.handleErrorResponse(resp.StatusCode, body)
+	}
+
+	tflog.Info(ctx, "Removed cache permission", map[string]any{
+		"cache":   cacheName,
+		"subject": permission.Subject(),
+	})
+
+	return nil
+}
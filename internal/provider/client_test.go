@@ -44,6 +44,23 @@ func TestNewCachixClient(t *testing.T) {
 		}
 	})
 
+	t.Run("applies retry options", func(t *testing.T) {
+		client := NewCachixClient("", "", "",
+			WithRetryMax(7),
+			WithRetryWait(100*time.Millisecond, 2*time.Second),
+		)
+
+		if client.retryMax != 7 {
+			t.Errorf("expected retryMax 7, got %d", client.retryMax)
+		}
+		if client.retryWaitMin != 100*time.Millisecond {
+			t.Errorf("expected retryWaitMin 100ms, got %v", client.retryWaitMin)
+		}
+		if client.retryWaitMax != 2*time.Second {
+			t.Errorf("expected retryWaitMax 2s, got %v", client.retryWaitMax)
+		}
+	})
+
 	t.Run("creates HTTP client with timeout", func(t *testing.T) {
 		client := NewCachixClient("", "", "")
 
@@ -56,6 +73,14 @@ func TestNewCachixClient(t *testing.T) {
 		}
 	})
 
+	t.Run("applies request timeout option", func(t *testing.T) {
+		client := NewCachixClient("", "", "", WithRequestTimeout(2*time.Minute))
+
+		if client.httpClient.Timeout != 2*time.Minute {
+			t.Errorf("expected timeout 2m, got %v", client.httpClient.Timeout)
+		}
+	})
+
 	t.Run("handles empty version", func(t *testing.T) {
 		client := NewCachixClient("https://api.cachix.org", "token", "")
 
@@ -66,107 +91,134 @@ func TestNewCachixClient(t *testing.T) {
 	})
 }
 
-func TestCachixClient_shouldRetry(t *testing.T) {
-	client := NewCachixClient("", "", "")
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name       string
-		statusCode int
-		expected   bool
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
 	}{
-		// Success responses - should NOT retry
-		{"200 OK", 200, false},
-		{"201 Created", 201, false},
-		{"204 No Content", 204, false},
-
-		// Client errors - should NOT retry
-		{"400 Bad Request", 400, false},
-		{"401 Unauthorized", 401, false},
-		{"403 Forbidden", 403, false},
-		{"404 Not Found", 404, false},
-		{"409 Conflict", 409, false},
-		{"422 Unprocessable Entity", 422, false},
-
-		// Rate limiting - SHOULD retry
-		{"429 Too Many Requests", 429, true},
-
-		// Server errors - SHOULD retry
-		{"500 Internal Server Error", 500, true},
-		{"501 Not Implemented", 501, true},
-		{"502 Bad Gateway", 502, true},
-		{"503 Service Unavailable", 503, true},
-		{"504 Gateway Timeout", 504, true},
+		{"seconds", "5", 5 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"padded seconds", " 12 ", 12 * time.Second, true},
+		{"http date", "Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"http date in the past", "Mon, 01 Jan 2024 11:59:00 GMT", 0, true},
+		{"empty", "", 0, false},
+		{"negative", "-1", 0, false},
+		{"garbage", "soon", 0, false},
 	}
 
 	for _, tt := range tests {
-		t.Run(fmt.Sprintf("status_%d_%s", tt.statusCode, tt.name), func(t *testing.T) {
-			got := client.shouldRetry(tt.statusCode)
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.ok)
+			}
 			if got != tt.expected {
-				t.Errorf("shouldRetry(%d) = %v, want %v", tt.statusCode, got, tt.expected)
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestCachixClient_calculateBackoff(t *testing.T) {
-	client := NewCachixClient("", "", "")
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
 
 	tests := []struct {
-		attempt     int
-		expectedMin time.Duration
-		expectedMax time.Duration
-		description string
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"delta seconds", "7", 7 * time.Second, true},
+		{"fractional delta seconds", "1.5", 1500 * time.Millisecond, true},
+		{"unix timestamp", "1700000010", 10 * time.Second, true},
+		{"unix timestamp in the past", "1699999990", 0, true},
+		{"empty", "", 0, false},
+		{"negative", "-3", 0, false},
+		{"garbage", "later", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRateLimitReset(tt.value, now)
+			if ok != tt.ok {
+				t.Fatalf("parseRateLimitReset(%q) ok = %v, want %v", tt.value, ok, tt.ok)
+			}
+			if got != tt.expected {
+				t.Errorf("parseRateLimitReset(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCachixClient_retryAfter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	client := NewCachixClient("", "", "", WithRetryWait(time.Second, 20*time.Second))
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedWait   time.Duration
+		expectedSource string
 	}{
 		{
-			attempt:     1,
-			expectedMin: 1 * time.Second,
-			expectedMax: 1 * time.Second,
-			description: "first retry: 1 second",
+			name:           "no headers",
+			expectedSource: "",
 		},
 		{
-			attempt:     2,
-			expectedMin: 2 * time.Second,
-			expectedMax: 2 * time.Second,
-			description: "second retry: 2 seconds",
+			name:           "Retry-After seconds",
+			headers:        map[string]string{"Retry-After": "3"},
+			expectedWait:   3 * time.Second,
+			expectedSource: "Retry-After",
 		},
 		{
-			attempt:     3,
-			expectedMin: 4 * time.Second,
-			expectedMax: 4 * time.Second,
-			description: "third retry: 4 seconds",
+			name:           "Retry-After preferred over rate-limit reset",
+			headers:        map[string]string{"Retry-After": "3", "X-RateLimit-Reset": "9"},
+			expectedWait:   3 * time.Second,
+			expectedSource: "Retry-After",
 		},
 		{
-			attempt:     4,
-			expectedMin: 8 * time.Second,
-			expectedMax: 8 * time.Second,
-			description: "fourth retry: 8 seconds",
+			name:           "X-RateLimit-Reset timestamp",
+			headers:        map[string]string{"X-RateLimit-Reset": "1700000004"},
+			expectedWait:   4 * time.Second,
+			expectedSource: "X-RateLimit-Reset",
 		},
 		{
-			attempt:     5,
-			expectedMin: 16 * time.Second,
-			expectedMax: 16 * time.Second,
-			description: "fifth retry: 16 seconds",
+			name:           "RateLimit-Reset seconds",
+			headers:        map[string]string{"RateLimit-Reset": "6"},
+			expectedWait:   6 * time.Second,
+			expectedSource: "RateLimit-Reset",
 		},
 		{
-			attempt:     6,
-			expectedMin: 30 * time.Second,
-			expectedMax: 30 * time.Second,
-			description: "sixth retry: capped at 30 seconds",
+			name:           "invalid Retry-After falls back to rate-limit reset",
+			headers:        map[string]string{"Retry-After": "soon", "X-Rate-Limit-Reset": "2"},
+			expectedWait:   2 * time.Second,
+			expectedSource: "X-Rate-Limit-Reset",
 		},
 		{
-			attempt:     10,
-			expectedMin: 30 * time.Second,
-			expectedMax: 30 * time.Second,
-			description: "large attempt: capped at 30 seconds",
+			name:           "capped at retry_wait_max",
+			headers:        map[string]string{"Retry-After": "3600"},
+			expectedWait:   20 * time.Second,
+			expectedSource: "Retry-After (capped)",
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.description, func(t *testing.T) {
-			got := client.calculateBackoff(tt.attempt)
-			if got < tt.expectedMin || got > tt.expectedMax {
-				t.Errorf("calculateBackoff(%d) = %v, want between %v and %v",
-					tt.attempt, got, tt.expectedMin, tt.expectedMax)
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+
+			wait, source := client.retryAfter(header, now)
+			if wait != tt.expectedWait {
+				t.Errorf("expected wait %v, got %v", tt.expectedWait, wait)
+			}
+			if source != tt.expectedSource {
+				t.Errorf("expected source %q, got %q", tt.expectedSource, source)
 			}
 		})
 	}
@@ -386,6 +438,48 @@ func TestIsNotFoundError(t *testing.T) {
 	}
 }
 
+func TestIsConflictError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "409 APIError returns true",
+			err:      &APIError{StatusCode: http.StatusConflict, Message: "cache already exists"},
+			expected: true,
+		},
+		{
+			name:     "wrapped 409 APIError returns true",
+			err:      fmt.Errorf("create failed: %w", &APIError{StatusCode: http.StatusConflict}),
+			expected: true,
+		},
+		{
+			name:     "404 APIError returns false",
+			err:      &APIError{StatusCode: http.StatusNotFound, Message: "not found"},
+			expected: false,
+		},
+		{
+			name:     "generic error returns false",
+			err:      errors.New("some error"),
+			expected: false,
+		},
+		{
+			name:     "nil error returns false",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConflictError(tt.err); got != tt.expected {
+				t.Errorf("IsConflictError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestDefaultConstants(t *testing.T) {
 	t.Run("DefaultRetryMax is 3", func(t *testing.T) {
 		if DefaultRetryMax != 3 {
@@ -406,6 +500,13 @@ func TestDefaultConstants(t *testing.T) {
 			t.Errorf("expected DefaultRetryWaitMax to be %v, got %v", expected, DefaultRetryWaitMax)
 		}
 	})
+
+	t.Run("DefaultRequestTimeout is 30 seconds", func(t *testing.T) {
+		expected := 30 * time.Second
+		if DefaultRequestTimeout != expected {
+			t.Errorf("expected DefaultRequestTimeout to be %v, got %v", expected, DefaultRequestTimeout)
+		}
+	})
 }
 
 func TestCacheStruct(t *testing.T) {
@@ -508,6 +609,25 @@ func TestCachixClient_GetCache_Success(t *testing.T) {
 	}
 }
 
+func TestCachixClient_GetCache_Owner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name": "test-cache", "uri": "https://test-cache.cachix.org", "githubUsername": "testuser"}`))
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.GetCache(context.Background(), "test-cache")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Owner != "testuser" {
+		t.Errorf("expected owner 'testuser', got %q", cache.Owner)
+	}
+}
+
 func TestCachixClient_GetCache_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -709,6 +829,258 @@ func TestCachixClient_CreateCache_Conflict(t *testing.T) {
 	}
 }
 
+func TestCachixClient_CreateCache_SelfManagedSigningKey(t *testing.T) {
+	var postCalled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(User{ID: 12345, Username: "testuser"})
+		case r.Method == http.MethodPost && r.URL.Path == "/cache/self-signed":
+			postCalled = true
+			var reqBody CreateCacheRequest
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			if reqBody.GenerateSigningKey {
+				t.Error("expected GenerateSigningKey to be false in request")
+			}
+			if reqBody.Priority != nil {
+				t.Errorf("expected no priority in request, got %d", *reqBody.Priority)
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/cache/self-signed":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Cache{
+				Name:              "self-signed",
+				URI:               "https://self-signed.cachix.org",
+				IsPublic:          true,
+				PublicSigningKeys: []string{},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.CreateCache(context.Background(), "self-signed", true, WithSelfManagedSigningKey())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !postCalled {
+		t.Error("expected POST to /cache/self-signed to be called")
+	}
+	if len(cache.PublicSigningKeys) != 0 {
+		t.Errorf("expected no public signing keys, got %v", cache.PublicSigningKeys)
+	}
+}
+
+func TestCachixClient_CreateCache_Priority(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/user":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(User{ID: 12345, Username: "testuser"})
+		case r.Method == http.MethodPost:
+			var reqBody CreateCacheRequest
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			if reqBody.Priority == nil || *reqBody.Priority != 30 {
+				t.Errorf("expected priority 30 in request, got %v", reqBody.Priority)
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Cache{Name: "my-cache", Priority: ptr[int64](30)})
+		}
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.CreateCache(context.Background(), "my-cache", true, WithPriority(30))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Priority == nil || *cache.Priority != 30 {
+		t.Errorf("expected priority 30, got %v", cache.Priority)
+	}
+}
+
+func TestWithRetention(t *testing.T) {
+	tests := []struct {
+		name          string
+		retentionDays int64
+		maxStorageGB  int64
+		expected      string
+	}{
+		{name: "both set", retentionDays: 14, maxStorageGB: 3, expected: `"retentionDays":14,"maxStorageSizeGB":3`},
+		{name: "retention only", retentionDays: 14, expected: `"retentionDays":14}`},
+		{name: "storage only", maxStorageGB: 3, expected: `"maxStorageSizeGB":3}`},
+		{name: "neither set", expected: `"accountID":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req CreateCacheRequest
+			WithRetention(tt.retentionDays, tt.maxStorageGB)(&req)
+
+			body, err := json.Marshal(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(body), tt.expected) {
+				t.Errorf("expected request body to contain %s, got %s", tt.expected, body)
+			}
+		})
+	}
+}
+
+func TestWithCompression(t *testing.T) {
+	var req CreateCacheRequest
+	WithCompression("zstd")(&req)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(body), `"compression":"zstd"`) {
+		t.Errorf("expected compression in request body, got %s", body)
+	}
+
+	body, err = json.Marshal(CreateCacheRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(body), "compression") {
+		t.Errorf("expected compression to be omitted when unset, got %s", body)
+	}
+}
+
+func TestCachixClient_UpdateCache_Success(t *testing.T) {
+	var patchCalled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/cache/my-cache":
+			patchCalled = true
+			var reqBody map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			if len(reqBody) != 1 || reqBody["isPublic"] != false {
+				t.Errorf("expected only isPublic=false in request, got %v", reqBody)
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/cache/my-cache":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Cache{
+				Name:     "my-cache",
+				URI:      "https://my-cache.cachix.org",
+				IsPublic: false,
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	isPublic := false
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.UpdateCache(context.Background(), "my-cache", UpdateCacheRequest{IsPublic: &isPublic})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !patchCalled {
+		t.Error("expected PATCH to /cache/my-cache to be called")
+	}
+	if cache.IsPublic {
+		t.Error("expected IsPublic to be false")
+	}
+}
+
+func TestCachixClient_UpdateCache_Priority(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPatch:
+			var reqBody map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			if len(reqBody) != 1 || reqBody["priority"] != float64(30) {
+				t.Errorf("expected only priority=30 in request, got %v", reqBody)
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Cache{Name: "my-cache", Priority: ptr[int64](30)})
+		}
+	}))
+	defer server.Close()
+
+	priority := int64(30)
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.UpdateCache(context.Background(), "my-cache", UpdateCacheRequest{Priority: &priority})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Priority == nil || *cache.Priority != 30 {
+		t.Errorf("expected priority 30, got %v", cache.Priority)
+	}
+}
+
+func TestCachixClient_UpdateCache_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "not the owner of this cache"}`))
+	}))
+	defer server.Close()
+
+	isPublic := true
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	cache, err := client.UpdateCache(context.Background(), "my-cache", UpdateCacheRequest{IsPublic: &isPublic})
+
+	if cache != nil {
+		t.Error("expected cache to be nil")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestUpdateCacheRequest_IsEmpty(t *testing.T) {
+	if !(UpdateCacheRequest{}).IsEmpty() {
+		t.Error("expected zero request to be empty")
+	}
+
+	isPublic := false
+	if (UpdateCacheRequest{IsPublic: &isPublic}).IsEmpty() {
+		t.Error("expected request with isPublic to be non-empty")
+	}
+}
+
 func TestCachixClient_DeleteCache_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request method
@@ -954,9 +1326,123 @@ func TestCachixClient_doRequest_MaxRetriesExceeded(t *testing.T) {
 	}
 }
 
-func TestCachixClient_doRequest_ContextCancellation(t *testing.T) {
-	var attemptCount int32
-
+func TestCachixClient_doRequest_HonorsRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     string
+		value      string
+		waitMin    time.Duration
+		waitMax    time.Duration
+		maxElapsed time.Duration
+	}{
+		{
+			// The default backoff would wait a full minute before retrying
+			name:       "429 with Retry-After zero retries immediately",
+			statusCode: http.StatusTooManyRequests,
+			header:     "Retry-After",
+			value:      "0",
+			waitMin:    time.Minute,
+			waitMax:    time.Minute,
+			maxElapsed: 5 * time.Second,
+		},
+		{
+			name:       "503 with reset header retries immediately",
+			statusCode: http.StatusServiceUnavailable,
+			header:     "X-RateLimit-Reset",
+			value:      "0",
+			waitMin:    time.Minute,
+			waitMax:    time.Minute,
+			maxElapsed: 5 * time.Second,
+		},
+		{
+			name:       "long Retry-After is capped by retry_wait_max",
+			statusCode: http.StatusTooManyRequests,
+			header:     "Retry-After",
+			value:      "3600",
+			waitMin:    time.Millisecond,
+			waitMax:    50 * time.Millisecond,
+			maxElapsed: 5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attemptCount int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attemptCount, 1) == 1 {
+					w.Header().Set(tt.header, tt.value)
+					w.WriteHeader(tt.statusCode)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(Cache{Name: "test-cache"})
+			}))
+			defer server.Close()
+
+			client := NewCachixClient(server.URL, "test-token", "1.0.0", WithRetryWait(tt.waitMin, tt.waitMax))
+
+			start := time.Now()
+			_, err := client.GetCache(context.Background(), "test-cache")
+			elapsed := time.Since(start)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if attempts := atomic.LoadInt32(&attemptCount); attempts != 2 {
+				t.Errorf("expected 2 attempts, got %d", attempts)
+			}
+			if elapsed > tt.maxElapsed {
+				t.Errorf("expected retry within %v, took %v", tt.maxElapsed, elapsed)
+			}
+		})
+	}
+}
+
+func TestCachixClient_doRequest_CustomRetryMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		retryMax int
+	}{
+		{"fail fast", 0},
+		{"one retry", 1},
+		{"more retries", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attemptCount int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attemptCount, 1)
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error": "rate limit exceeded"}`))
+			}))
+			defer server.Close()
+
+			client := NewCachixClient(server.URL, "test-token", "1.0.0",
+				WithRetryMax(tt.retryMax),
+				WithRetryWait(time.Millisecond, time.Millisecond),
+			)
+			_, err := client.GetCache(context.Background(), "test-cache")
+
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			expectedAttempts := int32(tt.retryMax + 1)
+			if finalAttempts := atomic.LoadInt32(&attemptCount); finalAttempts != expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", expectedAttempts, finalAttempts)
+			}
+		})
+	}
+}
+
+func TestCachixClient_doRequest_ContextCancellation(t *testing.T) {
+	var attemptCount int32
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		atomic.AddInt32(&attemptCount, 1)
 		// Always return 503 to trigger retry
@@ -994,6 +1480,106 @@ func TestCachixClient_doRequest_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestCachixClient_doRequest_RequestTimeoutRetried(t *testing.T) {
+	var attemptCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt hangs past the request timeout
+		if atomic.AddInt32(&attemptCount, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(Cache{Name: "test-cache"})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0",
+		WithRequestTimeout(50*time.Millisecond),
+		WithRetryPolicy(&ExponentialBackoff{WaitMin: time.Millisecond, WaitMax: time.Millisecond}),
+	)
+
+	cache, err := client.GetCache(context.Background(), "test-cache")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Name != "test-cache" {
+		t.Errorf("expected cache name 'test-cache', got '%s'", cache.Name)
+	}
+	if atomic.LoadInt32(&attemptCount) != 2 {
+		t.Errorf("expected 2 attempts, got %d", attemptCount)
+	}
+}
+
+func TestCachixClient_doRequest_OperationDeadline(t *testing.T) {
+	t.Run("deadline during request is not retried", func(t *testing.T) {
+		var attemptCount int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attemptCount, 1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		client := NewCachixClient(server.URL, "test-token", "1.0.0",
+			WithRetryPolicy(&ExponentialBackoff{WaitMin: time.Millisecond, WaitMax: time.Millisecond}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetCache(ctx, "test-cache")
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+		}
+		if atomic.LoadInt32(&attemptCount) != 1 {
+			t.Errorf("expected 1 attempt, got %d", attemptCount)
+		}
+	})
+
+	t.Run("retry wait beyond deadline fails fast", func(t *testing.T) {
+		var attemptCount int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attemptCount, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewCachixClient(server.URL, "test-token", "1.0.0",
+			WithRetryPolicy(&ExponentialBackoff{WaitMin: time.Minute, WaitMax: time.Minute}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		start := time.Now()
+		_, err := client.GetCache(ctx, "test-cache")
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), "status 503") {
+			t.Errorf("expected error to include the last failure, got: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected to fail without waiting for the deadline, took %v", elapsed)
+		}
+		if atomic.LoadInt32(&attemptCount) != 1 {
+			t.Errorf("expected 1 attempt, got %d", attemptCount)
+		}
+	})
+}
+
 func TestCachixClient_doRequest_NoRetryOn4xx(t *testing.T) {
 	var attemptCount int32
 
@@ -1189,3 +1775,453 @@ func TestCachixClient_GetCache_Forbidden(t *testing.T) {
 		t.Errorf("expected permission error message, got '%s'", apiErr.Message)
 	}
 }
+
+func TestCachixClient_CreateAuthToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/token" {
+			t.Errorf("expected /token, got %s", r.URL.Path)
+		}
+
+		var reqBody CreateAuthTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if reqBody.Description != "ci" {
+			t.Errorf("expected description 'ci', got %q", reqBody.Description)
+		}
+		if reqBody.ExpiresAt != "2030-01-01T00:00:00Z" {
+			t.Errorf("expected expiresAt '2030-01-01T00:00:00Z', got %q", reqBody.ExpiresAt)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(AuthToken{
+			ID:          "token-1",
+			Description: reqBody.Description,
+			ExpiresAt:   reqBody.ExpiresAt,
+			Secret:      "secret-value",
+		})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	token, err := client.CreateAuthToken(context.Background(), CreateAuthTokenRequest{
+		Description: "ci",
+		ExpiresAt:   "2030-01-01T00:00:00Z",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ID != "token-1" {
+		t.Errorf("expected id 'token-1', got %q", token.ID)
+	}
+	if token.Secret != "secret-value" {
+		t.Errorf("expected secret 'secret-value', got %q", token.Secret)
+	}
+}
+
+func TestCachixClient_CreateAuthToken_MissingSecret(t *testing.T) {
+	tests := []struct {
+		name          string
+		revokeStatus  int
+		expectMessage string
+	}{
+		{name: "revoked", revokeStatus: http.StatusNoContent, expectMessage: "token token-1 was revoked"},
+		{name: "revoke fails", revokeStatus: http.StatusForbidden, expectMessage: "revoke it manually"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var revocations int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/token":
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(AuthToken{ID: "token-1", Description: "ci"})
+				case r.Method == http.MethodDelete && r.URL.Path == "/token/token-1":
+					revocations++
+					w.WriteHeader(tt.revokeStatus)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := NewCachixClient(server.URL, "test-token", "1.0.0", WithRetryMax(0))
+			_, err := client.CreateAuthToken(context.Background(), CreateAuthTokenRequest{Description: "ci"})
+
+			if err == nil || !strings.Contains(err.Error(), tt.expectMessage) {
+				t.Errorf("expected error containing %q, got %v", tt.expectMessage, err)
+			}
+			if revocations != 1 {
+				t.Errorf("expected the token to be revoked once, got %d revocations", revocations)
+			}
+		})
+	}
+}
+
+func TestCachixClient_GetAuthToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/token/token-1" {
+			t.Errorf("expected /token/token-1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(AuthToken{ID: "token-1", Description: "ci", Revoked: true})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	token, err := client.GetAuthToken(context.Background(), "token-1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Description != "ci" {
+		t.Errorf("expected description 'ci', got %q", token.Description)
+	}
+	if !token.Revoked {
+		t.Error("expected token to be revoked")
+	}
+}
+
+func TestCachixClient_GetAuthToken_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	_, err := client.GetAuthToken(context.Background(), "token-1")
+
+	if !IsNotFoundError(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestCachixClient_RevokeAuthToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/token/token-1" {
+			t.Errorf("expected /token/token-1, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	if err := client.RevokeAuthToken(context.Background(), "token-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCachixClient_RevokeAuthToken_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "forbidden"})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	err := client.RevokeAuthToken(context.Background(), "token-1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 APIError, got %v", err)
+	}
+}
+
+func TestCachixClient_CreateCacheToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/token" {
+			t.Errorf("expected /cache/my-cache/token, got %s", r.URL.Path)
+		}
+
+		var reqBody CreateCacheTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if reqBody.Permission != "write" {
+			t.Errorf("expected permission 'write', got %q", reqBody.Permission)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(CacheToken{ID: "token-1", Permission: reqBody.Permission, Secret: "secret-value"})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	token, err := client.CreateCacheToken(context.Background(), "my-cache", CreateCacheTokenRequest{Permission: "write"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ID != "token-1" || token.Secret != "secret-value" {
+		t.Errorf("expected token-1 with secret, got %+v", token)
+	}
+}
+
+func TestCachixClient_CreateCacheToken_CacheNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	_, err := client.CreateCacheToken(context.Background(), "my-cache", CreateCacheTokenRequest{Permission: "read"})
+
+	if !IsNotFoundError(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestCachixClient_CreateCacheToken_MissingSecret(t *testing.T) {
+	var revocations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/cache/my-cache/token":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(CacheToken{ID: "token-1", Permission: "read"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/cache/my-cache/token/token-1":
+			revocations++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0", WithRetryMax(0))
+	_, err := client.CreateCacheToken(context.Background(), "my-cache", CreateCacheTokenRequest{Permission: "read"})
+
+	if err == nil || !strings.Contains(err.Error(), "token token-1 was revoked") {
+		t.Errorf("expected revoked token error, got %v", err)
+	}
+	if revocations != 1 {
+		t.Errorf("expected the token to be revoked once, got %d revocations", revocations)
+	}
+}
+
+func TestCachixClient_GetCacheToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/token/token-1" {
+			t.Errorf("expected /cache/my-cache/token/token-1, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(CacheToken{ID: "token-1", Permission: "read"})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	token, err := client.GetCacheToken(context.Background(), "my-cache", "token-1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Permission != "read" {
+		t.Errorf("expected permission 'read', got %q", token.Permission)
+	}
+}
+
+func TestCachixClient_RevokeCacheToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/token/token-1" {
+			t.Errorf("expected /cache/my-cache/token/token-1, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	if err := client.RevokeCacheToken(context.Background(), "my-cache", "token-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCachixClient_AddCacheSigningKey_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/key" {
+			t.Errorf("expected /cache/my-cache/key, got %s", r.URL.Path)
+		}
+
+		var reqBody map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if reqBody["publicKey"] != "my-cache-1:AAAA" {
+			t.Errorf("expected publicKey 'my-cache-1:AAAA', got %q", reqBody["publicKey"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	if err := client.AddCacheSigningKey(context.Background(), "my-cache", "my-cache-1:AAAA"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCachixClient_AddCacheSigningKey_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	err := client.AddCacheSigningKey(context.Background(), "my-cache", "my-cache-1:AAAA")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 APIError, got %v", err)
+	}
+}
+
+func TestCachixClient_RemoveCacheSigningKey_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		// The key is escaped into a single path segment
+		if r.URL.EscapedPath() != "/cache/my-cache/key/my-cache-1:AB%2FC+D==" {
+			t.Errorf("expected escaped key path, got %s", r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	if err := client.RemoveCacheSigningKey(context.Background(), "my-cache", "my-cache-1:AB/C+D=="); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCachixClient_ListCachePermissions_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/permissions" {
+			t.Errorf("expected /cache/my-cache/permissions, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]CachePermission{
+			{Username: "alice", Permission: "write"},
+			{Team: "my-org/ci", Permission: "read"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	permissions, err := client.ListCachePermissions(context.Background(), "my-cache")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(permissions) != 2 {
+		t.Fatalf("expected 2 permissions, got %d", len(permissions))
+	}
+	if permissions[1].Subject() != "my-org/ci" {
+		t.Errorf("expected subject 'my-org/ci', got %q", permissions[1].Subject())
+	}
+}
+
+func TestCachixClient_SetCachePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission CachePermission
+		path       string
+	}{
+		{
+			name:       "user",
+			permission: CachePermission{Username: "alice", Permission: "admin"},
+			path:       "/cache/my-cache/permissions/user/alice",
+		},
+		{
+			name:       "team",
+			permission: CachePermission{Team: "my-org/ci", Permission: "read"},
+			path:       "/cache/my-cache/permissions/team/my-org%2Fci",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("expected PUT, got %s", r.Method)
+				}
+				if r.URL.EscapedPath() != tt.path {
+					t.Errorf("expected %s, got %s", tt.path, r.URL.EscapedPath())
+				}
+
+				var reqBody map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				if reqBody["permission"] != tt.permission.Permission {
+					t.Errorf("expected permission %q, got %q", tt.permission.Permission, reqBody["permission"])
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewCachixClient(server.URL, "test-token", "1.0.0")
+			if err := client.SetCachePermission(context.Background(), "my-cache", tt.permission); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCachixClient_RemoveCachePermission_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/cache/my-cache/permissions/user/alice" {
+			t.Errorf("expected /cache/my-cache/permissions/user/alice, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewCachixClient(server.URL, "test-token", "1.0.0")
+	err := client.RemoveCachePermission(context.Background(), "my-cache", CachePermission{Username: "alice"})
+
+	if !IsNotFoundError(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
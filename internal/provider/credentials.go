@@ -0,0 +1,325 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Token sources reported in logs to show where the auth token was obtained.
+const (
+	tokenSourceProviderConfig = "provider configuration"
+	tokenSourceCommand        = "auth_token_command"
+	tokenSourceEnvironment    = "CACHIX_AUTH_TOKEN environment variable"
+	tokenSourceCachixConfig   = "cachix CLI config file"
+	tokenSourceNetrc          = "netrc file"
+)
+
+// DefaultAuthTokenCommandTimeout is the maximum time auth_token_command may run.
+const DefaultAuthTokenCommandTimeout = 30 * time.Second
+
+// authTokenCommandResult memoizes the output of auth_token_command so the
+// command runs at most once per provider instance.
+type authTokenCommandResult struct {
+	once  sync.Once
+	token string
+	err   error
+}
+
+// dhallAuthTokenPattern matches the authToken field written by `cachix authtoken`.
+var dhallAuthTokenPattern = regexp.MustCompile(`authToken\s*=\s*"([^"]*)"`)
+
+// defaultCachixConfigPath returns the location of the cachix CLI config file,
+// honoring XDG_CONFIG_HOME.
+func defaultCachixConfigPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "cachix", "cachix.dhall"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "cachix", "cachix.dhall"), nil
+}
+
+// readCachixConfigToken extracts the auth token from a cachix CLI config file.
+// Returns an empty string if the file does not contain a token.
+func readCachixConfigToken(configPath string) (string, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	match := dhallAuthTokenPattern.FindSubmatch(content)
+	if match == nil {
+		return "", nil
+	}
+
+	return string(match[1]), nil
+}
+
+// runAuthTokenCommand executes argv and returns its trimmed stdout as the token.
+// The command's stderr is included in the returned error on failure.
+func runAuthTokenCommand(ctx context.Context, argv []string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w\n\nstderr:\n%s", err, msg)
+		}
+		return "", err
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", errors.New("command produced no output on stdout")
+	}
+
+	return token, nil
+}
+
+// readNetrcPassword returns the password of the netrc entry whose machine
+// matches host, falling back to the default entry. The boolean result reports
+// whether a matching entry was found.
+func readNetrcPassword(netrcPath, host string) (string, bool, error) {
+	content, err := os.ReadFile(netrcPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	var (
+		defaultPassword string
+		hasDefault      bool
+		inMatch         bool
+		inDefault       bool
+	)
+
+	fields := strings.Fields(string(content))
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			if inMatch {
+				return "", false, nil
+			}
+			i++
+			inMatch = i < len(fields) && strings.EqualFold(fields[i], host)
+			inDefault = false
+		case "default":
+			if inMatch {
+				return "", false, nil
+			}
+			inMatch = false
+			inDefault = true
+			hasDefault = true
+		case "login", "account":
+			i++
+		case "password":
+			i++
+			if i >= len(fields) {
+				break
+			}
+			if inMatch {
+				return fields[i], true, nil
+			}
+			if inDefault {
+				defaultPassword = fields[i]
+			}
+		case "macdef":
+			// Macro definitions are not relevant for credentials; skip the name.
+			i++
+		}
+	}
+
+	if hasDefault {
+		return defaultPassword, true, nil
+	}
+
+	return "", false, nil
+}
+
+// resolveAuthToken walks the credential chain and returns the first token found
+// along with a description of its source. The chain is, in order: the auth_token
+// attribute, auth_token_command, the CACHIX_AUTH_TOKEN environment variable,
+// the cachix CLI config file and the netrc file. The default cachix CLI config
+// file is skipped when netrc_file is configured, so that a file the user named
+// is not overridden by one they did not. Returns an empty token if none of the
+// sources supplied one.
+func (p *CachixProvider) resolveAuthToken(ctx context.Context, config CachixProviderModel, apiHost string, diags *diag.Diagnostics) (token, source string) {
+	if !config.AuthToken.IsNull() && !config.AuthToken.IsUnknown() {
+		return config.AuthToken.ValueString(), tokenSourceProviderConfig
+	}
+
+	if !config.AuthTokenCommand.IsNull() && !config.AuthTokenCommand.IsUnknown() {
+		return p.resolveCommandToken(ctx, config, diags), tokenSourceCommand
+	}
+
+	if v := os.Getenv("CACHIX_AUTH_TOKEN"); v != "" {
+		return v, tokenSourceEnvironment
+	}
+
+	if config.ConfigFile.ValueString() == "" && config.NetrcFile.ValueString() != "" {
+		return resolveNetrcToken(ctx, config, apiHost, diags), tokenSourceNetrc
+	}
+
+	if token := resolveCachixConfigToken(ctx, config, diags); token != "" || diags.HasError() {
+		return token, tokenSourceCachixConfig
+	}
+
+	if token := resolveNetrcToken(ctx, config, apiHost, diags); token != "" || diags.HasError() {
+		return token, tokenSourceNetrc
+	}
+
+	return "", ""
+}
+
+// resolveCommandToken runs auth_token_command once per provider instance and
+// returns its output.
+func (p *CachixProvider) resolveCommandToken(ctx context.Context, config CachixProviderModel, diags *diag.Diagnostics) string {
+	var argv []string
+	diags.Append(config.AuthTokenCommand.ElementsAs(ctx, &argv, false)...)
+	if diags.HasError() {
+		return ""
+	}
+
+	if len(argv) == 0 || argv[0] == "" {
+		diags.AddAttributeError(
+			path.Root("auth_token_command"),
+			"Invalid Auth Token Command",
+			"The auth_token_command value must contain at least the program to run.",
+		)
+		return ""
+	}
+
+	p.authTokenCommand.once.Do(func() {
+		tflog.Debug(ctx, "Running auth_token_command", map[string]any{
+			"command": argv[0],
+		})
+		p.authTokenCommand.token, p.authTokenCommand.err = runAuthTokenCommand(ctx, argv, DefaultAuthTokenCommandTimeout)
+	})
+
+	if p.authTokenCommand.err != nil {
+		diags.AddAttributeError(
+			path.Root("auth_token_command"),
+			"Auth Token Command Failed",
+			fmt.Sprintf("The provider could not obtain a Cachix API token by running %q: %s", argv[0], p.authTokenCommand.err),
+		)
+		return ""
+	}
+
+	return p.authTokenCommand.token
+}
+
+// resolveCachixConfigToken reads the token from the config_file attribute or,
+// if unset, the default cachix CLI config file location. A missing default
+// file is not an error.
+func resolveCachixConfigToken(ctx context.Context, config CachixProviderModel, diags *diag.Diagnostics) string {
+	configPath := config.ConfigFile.ValueString()
+	explicitConfigFile := configPath != ""
+	if !explicitConfigFile {
+		var err error
+		configPath, err = defaultCachixConfigPath()
+		if err != nil {
+			tflog.Debug(ctx, "Unable to determine cachix CLI config file location", map[string]any{
+				"error": err.Error(),
+			})
+			return ""
+		}
+	}
+
+	token, err := readCachixConfigToken(configPath)
+	if err != nil {
+		if !explicitConfigFile && errors.Is(err, fs.ErrNotExist) {
+			tflog.Debug(ctx, "No cachix CLI config file found", map[string]any{
+				"config_file": configPath,
+			})
+			return ""
+		}
+		diags.AddAttributeError(
+			path.Root("config_file"),
+			"Unable to Read Cachix Config File",
+			fmt.Sprintf("The provider could not read the cachix CLI config file %q: %s", configPath, err),
+		)
+		return ""
+	}
+
+	if token != "" {
+		tflog.Debug(ctx, "Read auth token from cachix CLI config file", map[string]any{
+			"config_file": configPath,
+		})
+	}
+
+	return token
+}
+
+// resolveNetrcToken reads the token from the netrc file named by the
+// netrc_file attribute or the NETRC environment variable. It is skipped when
+// neither is set.
+func resolveNetrcToken(ctx context.Context, config CachixProviderModel, apiHost string, diags *diag.Diagnostics) string {
+	netrcPath := config.NetrcFile.ValueString()
+	if netrcPath == "" {
+		netrcPath = os.Getenv("NETRC")
+	}
+	if netrcPath == "" {
+		return ""
+	}
+
+	host := apiHost
+	if u, err := url.Parse(apiHost); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	token, found, err := readNetrcPassword(netrcPath, host)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("netrc_file"),
+			"Unable to Read netrc File",
+			fmt.Sprintf("The provider could not read the netrc file %q: %s", netrcPath, err),
+		)
+		return ""
+	}
+
+	if !found || token == "" {
+		diags.AddAttributeError(
+			path.Root("netrc_file"),
+			"No Matching netrc Entry",
+			fmt.Sprintf("The netrc file %q does not contain a password for machine %q. "+
+				"Add a \"machine %s password <token>\" entry or configure the token another way.", netrcPath, host, host),
+		)
+		return ""
+	}
+
+	tflog.Debug(ctx, "Read auth token from netrc file", map[string]any{
+		"netrc_file": netrcPath,
+		"host":       host,
+	})
+
+	return token
+}
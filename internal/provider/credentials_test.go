@@ -0,0 +1,515 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testAPIHost = "https://app.cachix.org/api/v1"
+
+const testNetrc = `machine other.example.com
+  login someone
+  password other-token
+
+machine app.cachix.org password netrc-token
+`
+
+const testCachixDhall = `{ authToken = "dhall-token"
+, hostname = "https://cachix.org"
+, binaryCaches = [] : List { name : Text, secretKey : Text }
+}
+`
+
+// writeTestCachixConfig writes a cachix CLI config file under dir/cachix.
+func writeTestCachixConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+
+	configPath := filepath.Join(dir, "cachix", "cachix.dhall")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	return configPath
+}
+
+func TestDefaultCachixConfigPath(t *testing.T) {
+	t.Run("honors XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+		got, err := defaultCachixConfigPath()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := filepath.Join("/tmp/xdg", "cachix", "cachix.dhall")
+		if got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("falls back to home directory", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", "/tmp/home")
+
+		got, err := defaultCachixConfigPath()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := filepath.Join("/tmp/home", ".config", "cachix", "cachix.dhall")
+		if got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	})
+}
+
+func TestReadCachixConfigToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"cachix authtoken output", testCachixDhall, "dhall-token"},
+		{"single line", `{ authToken = "abc.def", hostname = "https://cachix.org" }`, "abc.def"},
+		{"no token", `{ hostname = "https://cachix.org" }`, ""},
+		{"empty file", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := writeTestCachixConfig(t, t.TempDir(), tt.content)
+
+			got, err := readCachixConfigToken(configPath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected token %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readCachixConfigToken(filepath.Join(t.TempDir(), "missing.dhall"))
+		if err == nil {
+			t.Error("expected error for missing file, got nil")
+		}
+	})
+}
+
+// testAuthTokenCommand builds an auth_token_command value that runs script with sh.
+func testAuthTokenCommand(t *testing.T, script string) types.List {
+	t.Helper()
+
+	cmd, diags := types.ListValueFrom(context.Background(), types.StringType, []string{"sh", "-c", script})
+	if diags.HasError() {
+		t.Fatalf("failed to build command: %v", diags)
+	}
+
+	return cmd
+}
+
+func TestRunAuthTokenCommand(t *testing.T) {
+	t.Run("returns trimmed stdout", func(t *testing.T) {
+		token, err := runAuthTokenCommand(context.Background(), []string{"sh", "-c", "printf '  cmd-token\\n\\n'"}, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != "cmd-token" {
+			t.Errorf("expected token 'cmd-token', got %q", token)
+		}
+	})
+
+	t.Run("surfaces stderr on failure", func(t *testing.T) {
+		_, err := runAuthTokenCommand(context.Background(), []string{"sh", "-c", "echo 'vault: permission denied' >&2; exit 2"}, time.Second)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "vault: permission denied") {
+			t.Errorf("expected stderr in error, got: %v", err)
+		}
+	})
+
+	t.Run("fails on empty output", func(t *testing.T) {
+		_, err := runAuthTokenCommand(context.Background(), []string{"sh", "-c", "true"}, time.Second)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "no output") {
+			t.Errorf("expected empty output error, got: %v", err)
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		_, err := runAuthTokenCommand(context.Background(), []string{"sh", "-c", "sleep 5"}, 50*time.Millisecond)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("expected timeout error, got: %v", err)
+		}
+	})
+
+	t.Run("missing program", func(t *testing.T) {
+		_, err := runAuthTokenCommand(context.Background(), []string{"cachix-nonexistent-helper"}, time.Second)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+// writeTestNetrc writes a netrc file into a temporary directory.
+func writeTestNetrc(t *testing.T, content string) string {
+	t.Helper()
+
+	netrcPath := filepath.Join(t.TempDir(), "netrc")
+	if err := os.WriteFile(netrcPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write netrc file: %v", err)
+	}
+
+	return netrcPath
+}
+
+func TestReadNetrcPassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		host          string
+		expected      string
+		expectedFound bool
+	}{
+		{"matching machine", testNetrc, "app.cachix.org", "netrc-token", true},
+		{"other machine", testNetrc, "other.example.com", "other-token", true},
+		{"case insensitive host", testNetrc, "App.Cachix.Org", "netrc-token", true},
+		{"no match", testNetrc, "missing.example.com", "", false},
+		{"default entry", "machine other.example.com password x\ndefault login me password fallback\n", "app.cachix.org", "fallback", true},
+		{"machine without password", "machine app.cachix.org login me\nmachine other password x\n", "app.cachix.org", "", false},
+		{"empty file", "", "app.cachix.org", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			netrcPath := writeTestNetrc(t, tt.content)
+
+			got, found, err := readNetrcPassword(netrcPath, tt.host)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("expected found=%v, got %v", tt.expectedFound, found)
+			}
+			if got != tt.expected {
+				t.Errorf("expected password %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveAuthToken(t *testing.T) {
+	t.Run("explicit token takes precedence", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "env-token")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			AuthToken: types.StringValue("explicit-token"),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "explicit-token" || source != tokenSourceProviderConfig {
+			t.Errorf("expected explicit token from provider configuration, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("command before environment variable", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "env-token")
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			AuthTokenCommand: testAuthTokenCommand(t, "echo cmd-token"),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "cmd-token" || source != tokenSourceCommand {
+			t.Errorf("expected command token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("command runs once per provider instance", func(t *testing.T) {
+		counter := filepath.Join(t.TempDir(), "count")
+		config := CachixProviderModel{
+			AuthTokenCommand: testAuthTokenCommand(t, "echo x >> "+counter+"; echo cmd-token"),
+		}
+		p := &CachixProvider{}
+
+		for i := 0; i < 3; i++ {
+			var diags diag.Diagnostics
+			if token, _ := p.resolveAuthToken(context.Background(), config, testAPIHost, &diags); token != "cmd-token" {
+				t.Fatalf("expected token 'cmd-token', got %q (diags: %v)", token, diags)
+			}
+		}
+
+		content, err := os.ReadFile(counter)
+		if err != nil {
+			t.Fatalf("failed to read counter: %v", err)
+		}
+		if runs := strings.Count(string(content), "x"); runs != 1 {
+			t.Errorf("expected command to run once, ran %d times", runs)
+		}
+	})
+
+	t.Run("command failure is an error", func(t *testing.T) {
+		var diags diag.Diagnostics
+		(&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			AuthTokenCommand: testAuthTokenCommand(t, "echo 'not logged in' >&2; exit 1"),
+		}, testAPIHost, &diags)
+
+		if !diags.HasError() {
+			t.Fatal("expected error for failing command, got none")
+		}
+		if diags.Errors()[0].Summary() != "Auth Token Command Failed" {
+			t.Errorf("unexpected error summary: %s", diags.Errors()[0].Summary())
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), "not logged in") {
+			t.Errorf("expected stderr in detail, got: %s", diags.Errors()[0].Detail())
+		}
+	})
+
+	t.Run("environment variable before config file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCachixConfig(t, dir, testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "env-token")
+		t.Setenv("XDG_CONFIG_HOME", dir)
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "env-token" || source != tokenSourceEnvironment {
+			t.Errorf("expected env token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("falls back to XDG config file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCachixConfig(t, dir, testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", dir)
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "dhall-token" || source != tokenSourceCachixConfig {
+			t.Errorf("expected config file token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("uses explicit config_file", func(t *testing.T) {
+		configPath := writeTestCachixConfig(t, t.TempDir(), testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+		var diags diag.Diagnostics
+		token, _ := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			ConfigFile: types.StringValue(configPath),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "dhall-token" {
+			t.Errorf("expected token 'dhall-token', got %q", token)
+		}
+	})
+
+	t.Run("missing default config file is not an error", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("NETRC", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+		var diags diag.Diagnostics
+		token, _ := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "" {
+			t.Errorf("expected empty token, got %q", token)
+		}
+	})
+
+	t.Run("falls back to netrc_file", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			NetrcFile: types.StringValue(writeTestNetrc(t, testNetrc)),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "netrc-token" || source != tokenSourceNetrc {
+			t.Errorf("expected netrc token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("netrc_file before default config file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCachixConfig(t, dir, testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", dir)
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			NetrcFile: types.StringValue(writeTestNetrc(t, testNetrc)),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "netrc-token" || source != tokenSourceNetrc {
+			t.Errorf("expected netrc token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("explicit config_file before netrc_file", func(t *testing.T) {
+		configPath := writeTestCachixConfig(t, t.TempDir(), testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			ConfigFile: types.StringValue(configPath),
+			NetrcFile:  types.StringValue(writeTestNetrc(t, testNetrc)),
+		}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "dhall-token" || source != tokenSourceCachixConfig {
+			t.Errorf("expected config file token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("default config file before NETRC environment variable", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestCachixConfig(t, dir, testCachixDhall)
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", dir)
+		t.Setenv("NETRC", writeTestNetrc(t, testNetrc))
+
+		var diags diag.Diagnostics
+		token, source := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "dhall-token" || source != tokenSourceCachixConfig {
+			t.Errorf("expected config file token, got %q from %q", token, source)
+		}
+	})
+
+	t.Run("falls back to NETRC environment variable", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		t.Setenv("NETRC", writeTestNetrc(t, testNetrc))
+
+		var diags diag.Diagnostics
+		token, _ := (&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{}, testAPIHost, &diags)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if token != "netrc-token" {
+			t.Errorf("expected token 'netrc-token', got %q", token)
+		}
+	})
+
+	t.Run("netrc without matching machine names file and host", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		netrcPath := writeTestNetrc(t, testNetrc)
+
+		var diags diag.Diagnostics
+		(&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			NetrcFile: types.StringValue(netrcPath),
+		}, "https://cachix.example.com/api/v1", &diags)
+
+		if !diags.HasError() {
+			t.Fatal("expected error for unmatched netrc host, got none")
+		}
+		detail := diags.Errors()[0].Detail()
+		if !strings.Contains(detail, netrcPath) || !strings.Contains(detail, "cachix.example.com") {
+			t.Errorf("expected detail to name netrc file and host, got: %s", detail)
+		}
+	})
+
+	t.Run("missing explicit config_file is an error", func(t *testing.T) {
+		t.Setenv("CACHIX_AUTH_TOKEN", "")
+
+		var diags diag.Diagnostics
+		(&CachixProvider{}).resolveAuthToken(context.Background(), CachixProviderModel{
+			ConfigFile: types.StringValue(filepath.Join(t.TempDir(), "missing.dhall")),
+		}, testAPIHost, &diags)
+
+		if !diags.HasError() {
+			t.Fatal("expected error for missing config_file, got none")
+		}
+		if diags.Errors()[0].Summary() != "Unable to Read Cachix Config File" {
+			t.Errorf("unexpected error summary: %s", diags.Errors()[0].Summary())
+		}
+	})
+}
+
+func TestProvider_Configure_CachixConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCachixConfig(t, dir, testCachixDhall)
+	t.Setenv("CACHIX_AUTH_TOKEN", "")
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{})
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	client, ok := resp.DataSourceData.(*CachixClient)
+	if !ok {
+		t.Fatal("expected DataSourceData to be *CachixClient")
+	}
+	if client.authToken != "dhall-token" {
+		t.Errorf("expected auth token 'dhall-token', got '%s'", client.authToken)
+	}
+}
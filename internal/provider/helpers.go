@@ -8,12 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // cacheNameValidator is a shared validator for cache name fields.
@@ -27,6 +32,152 @@ func CacheNameValidators() []validator.String {
 	return []validator.String{cacheNameValidator}
 }
 
+// formatBytes formats a byte count for display, e.g. "1.5 GiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 5; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// durationValidator validates that a string attribute is a non-negative Go
+// duration such as "500ms", "5s" or "1m".
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v durationValidator) Description(ctx context.Context) string {
+	return `must be a duration such as "500ms", "5s" or "1m"`
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "must be a duration such as `500ms`, `5s` or `1m`"
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// rfc3339Validator validates that a string attribute is an RFC 3339
+// timestamp such as "2030-01-01T00:00:00Z".
+type rfc3339Validator struct{}
+
+var _ validator.String = rfc3339Validator{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return `must be an RFC 3339 timestamp such as "2030-01-01T00:00:00Z"`
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "must be an RFC 3339 timestamp such as `2030-01-01T00:00:00Z`"
+}
+
+// ValidateString performs the validation.
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// apiHostValidator validates that a string attribute is an absolute http or
+// https URL suitable as the Cachix API base URL.
+type apiHostValidator struct{}
+
+var _ validator.String = apiHostValidator{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v apiHostValidator) Description(ctx context.Context) string {
+	return `must be an absolute http or https URL such as "https://app.cachix.org/api/v1"`
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v apiHostValidator) MarkdownDescription(ctx context.Context) string {
+	return "must be an absolute http or https URL such as `https://app.cachix.org/api/v1`"
+}
+
+// ValidateString performs the validation.
+func (v apiHostValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := normalizeAPIHost(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid API Host",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// normalizeAPIHost validates an API base URL and strips surrounding
+// whitespace and trailing slashes, as request paths are appended to it.
+func normalizeAPIHost(raw string) (string, error) {
+	host := strings.TrimRight(strings.TrimSpace(raw), "/")
+	if host == "" {
+		return "", errors.New("URL must not be empty")
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("URL %q must start with http:// or https://", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("URL %q has no host", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("URL %q must not contain a query or fragment", raw)
+	}
+
+	return host, nil
+}
+
+// parseDuration parses a non-negative Go duration string.
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", s)
+	}
+	return d, nil
+}
+
 // getClientFromProviderData extracts the CachixClient from provider data.
 // Returns nil if provider data is nil (during early configuration).
 // Adds an error diagnostic if the type assertion fails.
@@ -75,6 +226,15 @@ func (h *APIErrorHandler) Handle(err error) bool {
 		return false
 	}
 
+	if errors.Is(err, context.DeadlineExceeded) {
+		h.Diagnostics.AddError(
+			"Operation Timed Out",
+			fmt.Sprintf("Unable to %s %s '%s' within the configured timeout. Increase the timeout for this operation and retry.\n\nError: %s",
+				h.Operation, h.ResourceType, h.ResourceName, err.Error()),
+		)
+		return true
+	}
+
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
 		switch apiErr.StatusCode {
@@ -130,6 +290,20 @@ func (h *APIErrorHandler) HandleNotFoundAsRemoved(err error) (shouldReturn bool,
 	return h.Handle(err), false
 }
 
+// HandleTokenRead handles the result of reading a token, treating a token
+// that was deleted or revoked outside Terraform as removed. Returns true if
+// the caller should return, after removing the resource from state if the
+// token is gone.
+func (h *APIErrorHandler) HandleTokenRead(ctx context.Context, err error, revoked bool, state *tfsdk.State, logFields map[string]any) bool {
+	shouldReturn, wasNotFound := h.HandleNotFoundAsRemoved(err)
+	if wasNotFound || (!shouldReturn && revoked) {
+		tflog.Warn(ctx, capitalize(h.ResourceType)+" revoked outside Terraform, removing from state", logFields)
+		state.RemoveResource(ctx)
+		return true
+	}
+	return shouldReturn
+}
+
 // CacheModel is a common interface for cache data models.
 type CacheModel interface {
 	SetID(types.String)
@@ -137,22 +311,67 @@ type CacheModel interface {
 	SetIsPublic(types.Bool)
 	SetURI(types.String)
 	SetPublicSigningKeys(types.List)
+	SetPriority(types.Int64)
+	SetRetentionDays(types.Int64)
+	SetMaxStorageSizeGB(types.Int64)
+	SetCompression(types.String)
+	SetCreatedAt(types.String)
+	SetOwner(types.String)
+	SetStorageBytes(types.Int64)
+	SetStorePathCount(types.Int64)
 }
 
 // mapCacheToState maps a Cache API response to the Terraform state model.
-func mapCacheToState(ctx context.Context, cache *Cache, diags *diag.Diagnostics) (
-	id, name types.String, isPublic types.Bool, uri types.String, publicSigningKeys types.List,
-) {
-	id = types.StringValue(cache.Name)
-	name = types.StringValue(cache.Name)
-	isPublic = types.BoolValue(cache.IsPublic)
-	uri = types.StringValue(cache.URI)
+// Optional settings the API does not report keep their current value.
+func mapCacheToState(ctx context.Context, cache *Cache, model CacheModel, diags *diag.Diagnostics) {
+	model.SetID(types.StringValue(cache.Name))
+	model.SetName(types.StringValue(cache.Name))
+	model.SetIsPublic(types.BoolValue(cache.IsPublic))
+	model.SetURI(types.StringValue(cache.URI))
+	if cache.Priority != nil {
+		model.SetPriority(types.Int64PointerValue(cache.Priority))
+	}
+	if cache.RetentionDays != nil {
+		model.SetRetentionDays(types.Int64PointerValue(cache.RetentionDays))
+	}
+	if cache.MaxStorageSizeGB != nil {
+		model.SetMaxStorageSizeGB(types.Int64PointerValue(cache.MaxStorageSizeGB))
+	}
+	if cache.Compression != nil {
+		model.SetCompression(types.StringPointerValue(cache.Compression))
+	}
+	model.SetCreatedAt(rfc3339Value(cache.CreatedAt, diags))
+	model.SetStorageBytes(types.Int64PointerValue(cache.StorageBytes))
+	model.SetStorePathCount(types.Int64PointerValue(cache.StorePathCount))
+
+	if cache.Owner != "" {
+		model.SetOwner(types.StringValue(cache.Owner))
+	} else {
+		model.SetOwner(types.StringNull())
+	}
 
 	keys, d := types.ListValueFrom(ctx, types.StringType, cache.PublicSigningKeys)
 	diags.Append(d...)
-	publicSigningKeys = keys
+	model.SetPublicSigningKeys(keys)
+}
+
+// rfc3339Value normalizes a timestamp returned by the API to RFC 3339 in UTC.
+// An empty timestamp maps to null.
+func rfc3339Value(raw string, diags *diag.Diagnostics) types.String {
+	if raw == "" {
+		return types.StringNull()
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		diags.AddWarning(
+			"Unexpected Timestamp Format",
+			fmt.Sprintf("Unable to parse the timestamp %q returned by the Cachix API as RFC 3339, using it as is: %s", raw, err),
+		)
+		return types.StringValue(raw)
+	}
 
-	return
+	return types.StringValue(t.UTC().Format(time.RFC3339))
 }
 
 // getOperationGerund returns the gerund form of an operation verb.
@@ -162,8 +381,12 @@ func getOperationGerund(operation string) string {
 		return "creating"
 	case "read":
 		return "reading"
+	case "update":
+		return "updating"
 	case "delete":
 		return "deleting"
+	case "revoke":
+		return "revoking"
 	default:
 		return operation + "ing"
 	}
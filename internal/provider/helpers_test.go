@@ -0,0 +1,159 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ptr returns a pointer to v, for optional fields of API types.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testCache() *Cache {
+	return &Cache{
+		Name:              "my-cache",
+		URI:               "https://my-cache.cachix.org",
+		IsPublic:          false,
+		PublicSigningKeys: []string{"my-cache.cachix.org-1:xxxx="},
+		Priority:          ptr[int64](30),
+		Compression:       ptr("zstd"),
+		CreatedAt:         "2024-03-01T12:30:45.123+01:00",
+		Owner:             "testuser",
+		StorageBytes:      ptr[int64](3 << 29),
+		StorePathCount:    ptr[int64](42),
+	}
+}
+
+func TestMapCacheToState_Resource(t *testing.T) {
+	var (
+		data  CacheResourceModel
+		diags diag.Diagnostics
+	)
+	mapCacheToState(context.Background(), testCache(), &data, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if data.ID.ValueString() != "my-cache" || data.Name.ValueString() != "my-cache" {
+		t.Errorf("expected id and name 'my-cache', got %q and %q", data.ID.ValueString(), data.Name.ValueString())
+	}
+	if data.URI.ValueString() != "https://my-cache.cachix.org" {
+		t.Errorf("expected uri 'https://my-cache.cachix.org', got %q", data.URI.ValueString())
+	}
+	if data.IsPublic.ValueBool() {
+		t.Error("expected is_public to be false")
+	}
+	if len(data.PublicSigningKeys.Elements()) != 1 {
+		t.Errorf("expected 1 public signing key, got %d", len(data.PublicSigningKeys.Elements()))
+	}
+	if data.Priority.ValueInt64() != 30 {
+		t.Errorf("expected priority 30, got %d", data.Priority.ValueInt64())
+	}
+	if data.Compression.ValueString() != "zstd" {
+		t.Errorf("expected compression 'zstd', got %q", data.Compression.ValueString())
+	}
+	if data.CreatedAt.ValueString() != "2024-03-01T11:30:45Z" {
+		t.Errorf("expected created_at '2024-03-01T11:30:45Z', got %q", data.CreatedAt.ValueString())
+	}
+	if data.Owner.ValueString() != "testuser" {
+		t.Errorf("expected owner 'testuser', got %q", data.Owner.ValueString())
+	}
+	if data.StorageBytes.ValueInt64() != 3<<29 || data.StorePathCount.ValueInt64() != 42 {
+		t.Errorf("expected 1610612736 bytes in 42 store paths, got %d bytes in %d", data.StorageBytes.ValueInt64(), data.StorePathCount.ValueInt64())
+	}
+}
+
+func TestMapCacheToState_DataSource(t *testing.T) {
+	var (
+		data  CacheDataSourceModel
+		diags diag.Diagnostics
+	)
+	mapCacheToState(context.Background(), testCache(), &data, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if data.ID.ValueString() != "my-cache" || data.Name.ValueString() != "my-cache" {
+		t.Errorf("expected id and name 'my-cache', got %q and %q", data.ID.ValueString(), data.Name.ValueString())
+	}
+	if data.URI.ValueString() != "https://my-cache.cachix.org" {
+		t.Errorf("expected uri 'https://my-cache.cachix.org', got %q", data.URI.ValueString())
+	}
+	if data.IsPublic.ValueBool() {
+		t.Error("expected is_public to be false")
+	}
+	if len(data.PublicSigningKeys.Elements()) != 1 {
+		t.Errorf("expected 1 public signing key, got %d", len(data.PublicSigningKeys.Elements()))
+	}
+	if data.Priority.ValueInt64() != 30 {
+		t.Errorf("expected priority 30, got %d", data.Priority.ValueInt64())
+	}
+	if data.Compression.ValueString() != "zstd" {
+		t.Errorf("expected compression 'zstd', got %q", data.Compression.ValueString())
+	}
+	if data.CreatedAt.ValueString() != "2024-03-01T11:30:45Z" {
+		t.Errorf("expected created_at '2024-03-01T11:30:45Z', got %q", data.CreatedAt.ValueString())
+	}
+	if data.Owner.ValueString() != "testuser" {
+		t.Errorf("expected owner 'testuser', got %q", data.Owner.ValueString())
+	}
+	if data.StorageBytes.ValueInt64() != 3<<29 || data.StorePathCount.ValueInt64() != 42 {
+		t.Errorf("expected 1610612736 bytes in 42 store paths, got %d bytes in %d", data.StorageBytes.ValueInt64(), data.StorePathCount.ValueInt64())
+	}
+}
+
+func TestRFC3339Value(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		expected    types.String
+		expectWarns bool
+	}{
+		{name: "empty", raw: "", expected: types.StringNull()},
+		{name: "utc", raw: "2024-03-01T12:30:45Z", expected: types.StringValue("2024-03-01T12:30:45Z")},
+		{name: "offset and fraction", raw: "2024-03-01T12:30:45.5-02:00", expected: types.StringValue("2024-03-01T14:30:45Z")},
+		{name: "unparseable", raw: "yesterday", expected: types.StringValue("yesterday"), expectWarns: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := rfc3339Value(tt.raw, &diags)
+
+			if !got.Equal(tt.expected) {
+				t.Errorf("rfc3339Value(%q) = %v, want %v", tt.raw, got, tt.expected)
+			}
+			if (diags.WarningsCount() > 0) != tt.expectWarns {
+				t.Errorf("expected warnings=%v, got: %v", tt.expectWarns, diags)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{bytes: 0, expected: "0 B"},
+		{bytes: 1023, expected: "1023 B"},
+		{bytes: 1024, expected: "1.0 KiB"},
+		{bytes: 3 << 29, expected: "1.5 GiB"},
+		{bytes: 5 << 40, expected: "5.0 TiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := formatBytes(tt.bytes); got != tt.expected {
+				t.Errorf("formatBytes(%d) = %q, want %q", tt.bytes, got, tt.expected)
+			}
+		})
+	}
+}
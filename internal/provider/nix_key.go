@@ -0,0 +1,101 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// NixKey is an ed25519 key in the Nix name:base64 format, as produced by
+// nix-store --generate-binary-cache-key.
+type NixKey struct {
+	Name string
+	Key  []byte
+}
+
+// parseNixKey parses a Nix-format key whose decoded key material must be
+// size bytes long: ed25519.PublicKeySize for public keys and
+// ed25519.PrivateKeySize for secret keys.
+func parseNixKey(s string, size int) (NixKey, error) {
+	name, encoded, ok := strings.Cut(s, ":")
+	if !ok {
+		return NixKey{}, errors.New("expected the format name:base64")
+	}
+	if name == "" || strings.ContainsAny(name, " \t\r\n") {
+		return NixKey{}, errors.New("key name must be non-empty and must not contain whitespace")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return NixKey{}, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != size {
+		return NixKey{}, fmt.Errorf("expected %d bytes of key material, got %d", size, len(key))
+	}
+
+	return NixKey{Name: name, Key: key}, nil
+}
+
+// checkNixKeyPair returns an error unless the secret key belongs to the
+// public key. Both keys must already be valid.
+func checkNixKeyPair(publicKey, secretKey NixKey) error {
+	if publicKey.Name != secretKey.Name {
+		return fmt.Errorf("secret key name %q does not match public key name %q", secretKey.Name, publicKey.Name)
+	}
+	derived := ed25519.PrivateKey(secretKey.Key).Public().(ed25519.PublicKey)
+	if !bytes.Equal(derived, publicKey.Key) {
+		return errors.New("secret key does not belong to the public key")
+	}
+	return nil
+}
+
+// nixKeyValidator validates that a string attribute is an ed25519 key in the
+// Nix name:base64 format.
+type nixKeyValidator struct {
+	// kind is "public" or "secret".
+	kind string
+	size int
+}
+
+var _ validator.String = nixKeyValidator{}
+
+// nixPublicKeyValidator validates Nix-format ed25519 public keys.
+var nixPublicKeyValidator = nixKeyValidator{kind: "public", size: ed25519.PublicKeySize}
+
+// nixSecretKeyValidator validates Nix-format ed25519 secret keys.
+var nixSecretKeyValidator = nixKeyValidator{kind: "secret", size: ed25519.PrivateKeySize}
+
+// Description returns a plain text description of the validator's behavior.
+func (v nixKeyValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("must be an ed25519 %s key in the Nix format name:base64, with %d bytes of key material", v.kind, v.size)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v nixKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("must be an ed25519 %s key in the Nix format `name:base64`, with %d bytes of key material", v.kind, v.size)
+}
+
+// ValidateString performs the validation. The value is left out of the
+// diagnostic, as it may be a secret.
+func (v nixKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseNixKey(req.ConfigValue.ValueString(), v.size); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Signing Key",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+// testNixKeyPair returns a Nix-format public and secret key pair with the
+// given name, derived from a fixed seed.
+func testNixKeyPair(t *testing.T, name string, seed byte) (publicKey, secretKey string) {
+	t.Helper()
+
+	secret := ed25519.NewKeyFromSeed(testSeed(seed))
+	public := secret.Public().(ed25519.PublicKey)
+
+	return name + ":" + base64.StdEncoding.EncodeToString(public),
+		name + ":" + base64.StdEncoding.EncodeToString(secret)
+}
+
+// testSeed returns an ed25519 seed filled with b.
+func testSeed(b byte) []byte {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = b
+	}
+	return seed
+}
+
+func TestParseNixKey(t *testing.T) {
+	publicKey, secretKey := testNixKeyPair(t, "my-cache-1", 1)
+
+	tests := []struct {
+		name        string
+		key         string
+		size        int
+		expectError bool
+	}{
+		{name: "public key", key: publicKey, size: ed25519.PublicKeySize},
+		{name: "secret key", key: secretKey, size: ed25519.PrivateKeySize},
+		{name: "secret key as public key", key: secretKey, size: ed25519.PublicKeySize, expectError: true},
+		{name: "missing name", key: publicKey[len("my-cache-1"):], size: ed25519.PublicKeySize, expectError: true},
+		{name: "missing separator", key: "my-cache-1", size: ed25519.PublicKeySize, expectError: true},
+		{name: "whitespace in name", key: "my cache" + publicKey[len("my-cache-1"):], size: ed25519.PublicKeySize, expectError: true},
+		{name: "invalid base64", key: "my-cache-1:not base64!", size: ed25519.PublicKeySize, expectError: true},
+		{name: "short key", key: "my-cache-1:" + base64.StdEncoding.EncodeToString([]byte("short")), size: ed25519.PublicKeySize, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := parseNixKey(tt.key, tt.size)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseNixKey() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err == nil && key.Name != "my-cache-1" {
+				t.Errorf("expected name 'my-cache-1', got %q", key.Name)
+			}
+		})
+	}
+}
+
+func TestCheckNixKeyPair(t *testing.T) {
+	publicKey, secretKey := testNixKeyPair(t, "my-cache-1", 1)
+	otherPublicKey, _ := testNixKeyPair(t, "my-cache-1", 2)
+	renamedPublicKey, _ := testNixKeyPair(t, "my-cache-2", 1)
+
+	tests := []struct {
+		name        string
+		publicKey   string
+		expectError bool
+	}{
+		{name: "matching pair", publicKey: publicKey},
+		{name: "different key", publicKey: otherPublicKey, expectError: true},
+		{name: "different name", publicKey: renamedPublicKey, expectError: true},
+	}
+
+	secret, err := parseNixKey(secretKey, ed25519.PrivateKeySize)
+	if err != nil {
+		t.Fatalf("failed to parse secret key: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			public, err := parseNixKey(tt.publicKey, ed25519.PublicKeySize)
+			if err != nil {
+				t.Fatalf("failed to parse public key: %v", err)
+			}
+
+			if err := checkNixKeyPair(public, secret); (err != nil) != tt.expectError {
+				t.Errorf("checkNixKeyPair() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
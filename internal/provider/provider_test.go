@@ -5,11 +5,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -31,6 +38,30 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// newTestProviderConfig builds a provider configuration from the given
+// attribute values, setting every other schema attribute to null.
+func newTestProviderConfig(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	attrTypes := make(map[string]tftypes.Type, len(s.Attributes))
+	attrValues := make(map[string]tftypes.Value, len(s.Attributes))
+	for name, attr := range s.Attributes {
+		attrType := attr.GetType().TerraformType(ctx)
+		attrTypes[name] = attrType
+		if v, ok := values[name]; ok {
+			attrValues[name] = v
+		} else {
+			attrValues[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(tftypes.Object{AttributeTypes: attrTypes}, attrValues),
+		Schema: s,
+	}
+}
+
 func TestProvider_Metadata(t *testing.T) {
 	p := New("1.0.0")()
 
@@ -146,21 +177,11 @@ func TestProvider_Configure_WithEnvVar(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create an empty config (no explicit token)
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, nil),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -193,21 +214,11 @@ func TestProvider_Configure_WithExplicitToken(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create config with explicit token
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, "explicit-test-token"),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -240,21 +251,11 @@ func TestProvider_Configure_TokenPrecedence(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create config with explicit token (should override env var)
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, "explicit-token"),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -290,21 +291,11 @@ func TestProvider_Configure_CustomAPIHost(t *testing.T) {
 
 	// Create config with custom API host
 	customHost := "https://custom.cachix.org/api/v2"
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, nil),
 		"api_host":   tftypes.NewValue(tftypes.String, customHost),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -327,6 +318,157 @@ func TestProvider_Configure_CustomAPIHost(t *testing.T) {
 	}
 }
 
+func TestNormalizeAPIHost(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "https://app.cachix.org/api/v1", expected: "https://app.cachix.org/api/v1"},
+		{input: "https://app.cachix.org/api/v1/", expected: "https://app.cachix.org/api/v1"},
+		{input: " http://localhost:8080// ", expected: "http://localhost:8080"},
+		{input: "app.cachix.org/api/v1", wantErr: true},
+		{input: "ftp://app.cachix.org", wantErr: true},
+		{input: "https://", wantErr: true},
+		{input: "https://app.cachix.org/api/v1?debug=1", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := normalizeAPIHost(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_APIHostNormalization(t *testing.T) {
+	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
+
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+	t.Run("trailing slash is removed", func(t *testing.T) {
+		config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"api_host": tftypes.NewValue(tftypes.String, "https://custom.cachix.org/api/v1/"),
+		})
+
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		client := resp.DataSourceData.(*CachixClient)
+		if client.baseURL != "https://custom.cachix.org/api/v1" {
+			t.Errorf("expected normalized base URL, got '%s'", client.baseURL)
+		}
+	})
+
+	t.Run("missing scheme is rejected", func(t *testing.T) {
+		config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"api_host": tftypes.NewValue(tftypes.String, "custom.cachix.org/api/v1"),
+		})
+
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error, got none")
+		}
+		if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid API Host" {
+			t.Errorf("expected 'Invalid API Host', got %q", summary)
+		}
+		if resp.DataSourceData != nil {
+			t.Error("expected DataSourceData to be nil on error")
+		}
+	})
+}
+
+func TestProvider_Configure_VerifyCredentials(t *testing.T) {
+	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
+
+	tests := []struct {
+		name            string
+		status          int
+		expectedSummary string
+	}{
+		{name: "valid token", status: http.StatusOK},
+		{name: "rejected token", status: http.StatusUnauthorized, expectedSummary: "Invalid Cachix API Token"},
+		{name: "client error", status: http.StatusBadRequest, expectedSummary: "Unable to Verify Cachix Credentials"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				if r.URL.Path != "/user" {
+					t.Errorf("expected path /user, got %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(User{ID: 1, Username: "testuser"})
+					return
+				}
+				_, _ = w.Write([]byte(`{"error": "invalid token"}`))
+			}))
+			defer server.Close()
+
+			p := New("test")()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+			config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+				"api_host":           tftypes.NewValue(tftypes.String, server.URL),
+				"verify_credentials": tftypes.NewValue(tftypes.Bool, true),
+			})
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if atomic.LoadInt32(&requests) != 1 {
+				t.Errorf("expected 1 request to /user, got %d", requests)
+			}
+
+			if tt.expectedSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				if resp.DataSourceData == nil {
+					t.Error("expected DataSourceData to be set")
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error, got none")
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.expectedSummary {
+				t.Errorf("expected %q, got %q", tt.expectedSummary, summary)
+			}
+			if resp.DataSourceData != nil {
+				t.Error("expected DataSourceData to be nil on error")
+			}
+		})
+	}
+}
+
 func TestProvider_Configure_DefaultAPIHost(t *testing.T) {
 	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
 
@@ -338,21 +480,11 @@ func TestProvider_Configure_DefaultAPIHost(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create config without custom API host (should use default)
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, nil),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -379,6 +511,7 @@ func TestProvider_Configure_DefaultAPIHost(t *testing.T) {
 func TestProvider_Configure_MissingToken(t *testing.T) {
 	// Ensure no token is available
 	t.Setenv("CACHIX_AUTH_TOKEN", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 
 	p := New("test")()
 
@@ -388,21 +521,11 @@ func TestProvider_Configure_MissingToken(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create config without token
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, nil),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -439,6 +562,7 @@ func TestProvider_Configure_MissingToken(t *testing.T) {
 func TestProvider_Configure_EmptyExplicitToken(t *testing.T) {
 	// Ensure no token from env
 	t.Setenv("CACHIX_AUTH_TOKEN", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 
 	p := New("test")()
 
@@ -448,21 +572,11 @@ func TestProvider_Configure_EmptyExplicitToken(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create config with empty string token
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, ""),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -515,7 +629,7 @@ func TestProvider_Resources(t *testing.T) {
 	resources := p.Resources(context.Background())
 
 	// Verify expected number of resources
-	expectedCount := 1 // cache
+	expectedCount := 5 // auth_token, cache, cache_permission, cache_signing_key, cache_token
 	if len(resources) != expectedCount {
 		t.Errorf("expected %d resources, got %d", expectedCount, len(resources))
 	}
@@ -529,6 +643,119 @@ func TestProvider_Resources(t *testing.T) {
 	}
 }
 
+func TestProvider_Resources_TypeNames(t *testing.T) {
+	tests := []struct {
+		newResource func() resource.Resource
+		expected    string
+	}{
+		{newResource: NewAuthTokenResource, expected: "cachix_auth_token"},
+		{newResource: NewCacheTokenResource, expected: "cachix_cache_token"},
+		{newResource: NewCacheSigningKeyResource, expected: "cachix_cache_signing_key"},
+		{newResource: NewCachePermissionResource, expected: "cachix_cache_permission"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			resp := &resource.MetadataResponse{}
+			tt.newResource().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "cachix"}, resp)
+
+			if resp.TypeName != tt.expected {
+				t.Errorf("expected TypeName '%s', got '%s'", tt.expected, resp.TypeName)
+			}
+		})
+	}
+}
+
+// TestResources_Delete checks that destroying a resource sends a single
+// DELETE request, and succeeds when it was already removed outside Terraform.
+func TestResources_Delete(t *testing.T) {
+	publicKey, _ := testNixKeyPair(t, "my-cache-1", 1)
+
+	resources := []struct {
+		name        string
+		newResource func() resource.Resource
+		state       map[string]tftypes.Value
+		path        string
+	}{
+		{
+			name:        "auth token",
+			newResource: NewAuthTokenResource,
+			state: map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "token-1"),
+				"description": tftypes.NewValue(tftypes.String, "ci"),
+			},
+			path: "/token/token-1",
+		},
+		{
+			name:        "cache token",
+			newResource: NewCacheTokenResource,
+			state: map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, "token-1"),
+				"cache": tftypes.NewValue(tftypes.String, "my-cache"),
+			},
+			path: "/cache/my-cache/token/token-1",
+		},
+		{
+			name:        "cache signing key",
+			newResource: NewCacheSigningKeyResource,
+			state: map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, "my-cache/my-cache-1"),
+				"cache":      tftypes.NewValue(tftypes.String, "my-cache"),
+				"public_key": tftypes.NewValue(tftypes.String, publicKey),
+			},
+			path: "/cache/my-cache/key/" + publicKey,
+		},
+		{
+			name:        "cache permission",
+			newResource: NewCachePermissionResource,
+			state: map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, "my-cache/alice"),
+				"cache":    tftypes.NewValue(tftypes.String, "my-cache"),
+				"username": tftypes.NewValue(tftypes.String, "alice"),
+			},
+			path: "/cache/my-cache/permissions/user/alice",
+		},
+	}
+	statuses := []struct {
+		name      string
+		status    int
+		expectErr bool
+	}{
+		{name: "removed", status: http.StatusNoContent},
+		{name: "already removed", status: http.StatusNotFound},
+		{name: "forbidden", status: http.StatusForbidden, expectErr: true},
+	}
+
+	for _, rt := range resources {
+		for _, st := range statuses {
+			t.Run(rt.name+"/"+st.name, func(t *testing.T) {
+				var deletes int
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodDelete || r.URL.Path != rt.path {
+						t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					}
+					deletes++
+					w.WriteHeader(st.status)
+				}))
+				defer server.Close()
+
+				r, s := newTestResource(t, rt.newResource, server.URL)
+				state := tfsdk.State{Schema: s, Raw: newTestResourceValue(t, s, rt.state)}
+				resp := &resource.DeleteResponse{State: state}
+
+				r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+
+				if resp.Diagnostics.HasError() != st.expectErr {
+					t.Errorf("expected error=%v, got diagnostics: %v", st.expectErr, resp.Diagnostics)
+				}
+				if deletes != 1 {
+					t.Errorf("expected 1 delete request, got %d", deletes)
+				}
+			})
+		}
+	}
+}
+
 func TestNew_ReturnsProviderFactory(t *testing.T) {
 	factory := New("1.2.3")
 
@@ -581,21 +808,11 @@ func TestProvider_Configure_ClientVersion(t *testing.T) {
 	schemaResp := &provider.SchemaResponse{}
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"auth_token": tftypes.String,
-			"api_host":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
+	config := newTestProviderConfig(t, schemaResp.Schema, map[string]tftypes.Value{
 		"auth_token": tftypes.NewValue(tftypes.String, nil),
 		"api_host":   tftypes.NewValue(tftypes.String, nil),
 	})
 
-	config := tfsdk.Config{
-		Raw:    configValue,
-		Schema: schemaResp.Schema,
-	}
-
 	req := provider.ConfigureRequest{
 		Config: config,
 	}
@@ -618,3 +835,230 @@ func TestProvider_Configure_ClientVersion(t *testing.T) {
 		t.Errorf("expected user agent '%s', got '%s'", expectedUserAgent, client.userAgent)
 	}
 }
+
+func TestProvider_Configure_RetrySettings(t *testing.T) {
+	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
+	t.Setenv("CACHIX_RETRY_MAX", "")
+	t.Setenv("CACHIX_RETRY_WAIT_MIN", "")
+	t.Setenv("CACHIX_RETRY_WAIT_MAX", "")
+
+	tests := []struct {
+		name            string
+		env             map[string]string
+		values          map[string]tftypes.Value
+		expectedMax     int
+		expectedWaitMin time.Duration
+		expectedWaitMax time.Duration
+	}{
+		{
+			name:            "defaults",
+			expectedMax:     DefaultRetryMax,
+			expectedWaitMin: DefaultRetryWaitMin,
+			expectedWaitMax: DefaultRetryWaitMax,
+		},
+		{
+			name: "provider attributes",
+			values: map[string]tftypes.Value{
+				"retry_max":      tftypes.NewValue(tftypes.Number, 10),
+				"retry_wait_min": tftypes.NewValue(tftypes.String, "2s"),
+				"retry_wait_max": tftypes.NewValue(tftypes.String, "2m"),
+			},
+			expectedMax:     10,
+			expectedWaitMin: 2 * time.Second,
+			expectedWaitMax: 2 * time.Minute,
+		},
+		{
+			name: "environment variables",
+			env: map[string]string{
+				"CACHIX_RETRY_MAX":      "0",
+				"CACHIX_RETRY_WAIT_MIN": "100ms",
+				"CACHIX_RETRY_WAIT_MAX": "500ms",
+			},
+			expectedMax:     0,
+			expectedWaitMin: 100 * time.Millisecond,
+			expectedWaitMax: 500 * time.Millisecond,
+		},
+		{
+			name: "attributes override environment variables",
+			env: map[string]string{
+				"CACHIX_RETRY_MAX":      "1",
+				"CACHIX_RETRY_WAIT_MAX": "5s",
+			},
+			values: map[string]tftypes.Value{
+				"retry_max":      tftypes.NewValue(tftypes.Number, 8),
+				"retry_wait_max": tftypes.NewValue(tftypes.String, "10s"),
+			},
+			expectedMax:     8,
+			expectedWaitMin: DefaultRetryWaitMin,
+			expectedWaitMax: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			p := New("test")()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+			config := newTestProviderConfig(t, schemaResp.Schema, tt.values)
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			client, ok := resp.DataSourceData.(*CachixClient)
+			if !ok {
+				t.Fatal("expected DataSourceData to be *CachixClient")
+			}
+			if client.retryMax != tt.expectedMax {
+				t.Errorf("expected retryMax %d, got %d", tt.expectedMax, client.retryMax)
+			}
+			if client.retryWaitMin != tt.expectedWaitMin {
+				t.Errorf("expected retryWaitMin %v, got %v", tt.expectedWaitMin, client.retryWaitMin)
+			}
+			if client.retryWaitMax != tt.expectedWaitMax {
+				t.Errorf("expected retryWaitMax %v, got %v", tt.expectedWaitMax, client.retryWaitMax)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_RequestTimeout(t *testing.T) {
+	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
+	t.Setenv("CACHIX_REQUEST_TIMEOUT", "")
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		values   map[string]tftypes.Value
+		expected time.Duration
+	}{
+		{
+			name:     "default",
+			expected: DefaultRequestTimeout,
+		},
+		{
+			name: "provider attribute",
+			values: map[string]tftypes.Value{
+				"request_timeout": tftypes.NewValue(tftypes.String, "2m"),
+			},
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "environment variable",
+			env:      map[string]string{"CACHIX_REQUEST_TIMEOUT": "45s"},
+			expected: 45 * time.Second,
+		},
+		{
+			name: "disabled",
+			values: map[string]tftypes.Value{
+				"request_timeout": tftypes.NewValue(tftypes.String, "0s"),
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			p := New("test")()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+			config := newTestProviderConfig(t, schemaResp.Schema, tt.values)
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			client, ok := resp.DataSourceData.(*CachixClient)
+			if !ok {
+				t.Fatal("expected DataSourceData to be *CachixClient")
+			}
+			if client.httpClient.Timeout != tt.expected {
+				t.Errorf("expected request timeout %v, got %v", tt.expected, client.httpClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_InvalidRetrySettings(t *testing.T) {
+	t.Setenv("CACHIX_AUTH_TOKEN", "test-token")
+	t.Setenv("CACHIX_RETRY_MAX", "")
+	t.Setenv("CACHIX_RETRY_WAIT_MIN", "")
+	t.Setenv("CACHIX_RETRY_WAIT_MAX", "")
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		values map[string]tftypes.Value
+	}{
+		{
+			name: "min greater than max",
+			values: map[string]tftypes.Value{
+				"retry_wait_min": tftypes.NewValue(tftypes.String, "1m"),
+				"retry_wait_max": tftypes.NewValue(tftypes.String, "10s"),
+			},
+		},
+		{
+			name: "invalid duration",
+			values: map[string]tftypes.Value{
+				"retry_wait_min": tftypes.NewValue(tftypes.String, "soon"),
+			},
+		},
+		{
+			name: "invalid retry max environment variable",
+			env:  map[string]string{"CACHIX_RETRY_MAX": "many"},
+		},
+		{
+			name: "invalid wait environment variable",
+			env:  map[string]string{"CACHIX_RETRY_WAIT_MAX": "-5s"},
+		},
+		{
+			name: "invalid request timeout",
+			values: map[string]tftypes.Value{
+				"request_timeout": tftypes.NewValue(tftypes.String, "forever"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			p := New("test")()
+
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+
+			config := newTestProviderConfig(t, schemaResp.Schema, tt.values)
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error, got none")
+			}
+			if resp.DataSourceData != nil {
+				t.Error("expected DataSourceData to be nil on error")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides which failed requests are retried and how long to wait
+// before each retry.
+type RetryPolicy interface {
+	// ShouldRetry reports whether a request should be retried. statusCode is
+	// zero when the request failed with err before a response was received.
+	ShouldRetry(statusCode int, err error) bool
+	// Backoff returns the wait before the given retry attempt, starting at 1.
+	// previous is the wait used before the prior attempt, or zero.
+	Backoff(attempt int, previous time.Duration) time.Duration
+}
+
+var (
+	_ RetryPolicy = &ExponentialBackoff{}
+	_ RetryPolicy = &FullJitterBackoff{}
+	_ RetryPolicy = &DecorrelatedJitterBackoff{}
+)
+
+// ExponentialBackoff waits WaitMin * 2^(attempt-1), capped at WaitMax. It is
+// deterministic, which makes it suitable for tests.
+type ExponentialBackoff struct {
+	WaitMin time.Duration
+	WaitMax time.Duration
+}
+
+// ShouldRetry reports whether a request should be retried.
+func (b *ExponentialBackoff) ShouldRetry(statusCode int, err error) bool {
+	return shouldRetryRequest(statusCode, err)
+}
+
+// Backoff returns the wait before the given retry attempt.
+func (b *ExponentialBackoff) Backoff(attempt int, previous time.Duration) time.Duration {
+	return exponentialWait(b.WaitMin, b.WaitMax, attempt)
+}
+
+// FullJitterBackoff waits a random duration between WaitMin and the
+// exponential backoff for the attempt, so that concurrent clients failing at
+// the same time do not retry in lockstep.
+type FullJitterBackoff struct {
+	WaitMin time.Duration
+	WaitMax time.Duration
+
+	// random returns a number in [0, 1). Defaults to math/rand.
+	random func() float64
+}
+
+// ShouldRetry reports whether a request should be retried.
+func (b *FullJitterBackoff) ShouldRetry(statusCode int, err error) bool {
+	return shouldRetryRequest(statusCode, err)
+}
+
+// Backoff returns the wait before the given retry attempt.
+func (b *FullJitterBackoff) Backoff(attempt int, previous time.Duration) time.Duration {
+	ceiling := exponentialWait(b.WaitMin, b.WaitMax, attempt)
+	return randomBetween(b.WaitMin, ceiling, b.random)
+}
+
+// DecorrelatedJitterBackoff waits a random duration between WaitMin and three
+// times the previous wait, capped at WaitMax.
+type DecorrelatedJitterBackoff struct {
+	WaitMin time.Duration
+	WaitMax time.Duration
+
+	// random returns a number in [0, 1). Defaults to math/rand.
+	random func() float64
+}
+
+// ShouldRetry reports whether a request should be retried.
+func (b *DecorrelatedJitterBackoff) ShouldRetry(statusCode int, err error) bool {
+	return shouldRetryRequest(statusCode, err)
+}
+
+// Backoff returns the wait before the given retry attempt.
+func (b *DecorrelatedJitterBackoff) Backoff(attempt int, previous time.Duration) time.Duration {
+	if previous < b.WaitMin {
+		previous = b.WaitMin
+	}
+
+	ceiling := previous * 3
+	if ceiling > b.WaitMax || ceiling < previous {
+		ceiling = b.WaitMax
+	}
+
+	return randomBetween(b.WaitMin, ceiling, b.random)
+}
+
+// exponentialWait returns waitMin * 2^(attempt-1), capped at waitMax.
+func exponentialWait(waitMin, waitMax time.Duration, attempt int) time.Duration {
+	wait := float64(waitMin) * math.Pow(2, float64(attempt-1))
+	if wait > float64(waitMax) {
+		wait = float64(waitMax)
+	}
+	return time.Duration(wait)
+}
+
+// randomBetween returns a random duration in [low, high].
+func randomBetween(low, high time.Duration, random func() float64) time.Duration {
+	if high <= low {
+		return low
+	}
+	if random == nil {
+		random = rand.Float64
+	}
+	return low + time.Duration(random()*float64(high-low))
+}
+
+// shouldRetryRequest is the retry condition shared by the built-in policies.
+func shouldRetryRequest(statusCode int, err error) bool {
+	if err != nil {
+		return IsRetryableError(err)
+	}
+	return IsRetryableStatus(statusCode)
+}
+
+// IsRetryableStatus reports whether a response status code indicates a
+// transient failure: server errors (5xx) and rate limiting (429).
+func IsRetryableStatus(statusCode int) bool {
+	return statusCode >= 500 || statusCode == 429
+}
+
+// IsRetryableError reports whether a transport error is transient. Connection
+// resets, unexpected EOFs, refused connections and timeouts are retried;
+// cancellation, TLS verification failures and unknown hosts are not, as
+// retrying cannot change their outcome.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var (
+		certErr      *tls.CertificateVerificationError
+		unknownAuth  x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		certInvalid  x509.CertificateInvalidError
+		recordHeader tls.RecordHeaderError
+	)
+	if errors.As(err, &certErr) || errors.As(err, &unknownAuth) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &certInvalid) || errors.As(err, &recordHeader) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -0,0 +1,368 @@
+// Copyright (c) takeokunn
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		expected   bool
+	}{
+		// Success responses - should NOT retry
+		{"200 OK", 200, false},
+		{"201 Created", 201, false},
+		{"204 No Content", 204, false},
+
+		// Client errors - should NOT retry
+		{"400 Bad Request", 400, false},
+		{"401 Unauthorized", 401, false},
+		{"403 Forbidden", 403, false},
+		{"404 Not Found", 404, false},
+		{"409 Conflict", 409, false},
+		{"422 Unprocessable Entity", 422, false},
+
+		// Rate limiting - SHOULD retry
+		{"429 Too Many Requests", 429, true},
+
+		// Server errors - SHOULD retry
+		{"500 Internal Server Error", 500, true},
+		{"501 Not Implemented", 501, true},
+		{"502 Bad Gateway", 502, true},
+		{"503 Service Unavailable", 503, true},
+		{"504 Gateway Timeout", 504, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("status_%d_%s", tt.statusCode, tt.name), func(t *testing.T) {
+			got := IsRetryableStatus(tt.statusCode)
+			if got != tt.expected {
+				t.Errorf("IsRetryableStatus(%d) = %v, want %v", tt.statusCode, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_Backoff(t *testing.T) {
+	policy := &ExponentialBackoff{WaitMin: DefaultRetryWaitMin, WaitMax: DefaultRetryWaitMax}
+
+	tests := []struct {
+		attempt     int
+		expectedMin time.Duration
+		expectedMax time.Duration
+		description string
+	}{
+		{
+			attempt:     1,
+			expectedMin: 1 * time.Second,
+			expectedMax: 1 * time.Second,
+			description: "first retry: 1 second",
+		},
+		{
+			attempt:     2,
+			expectedMin: 2 * time.Second,
+			expectedMax: 2 * time.Second,
+			description: "second retry: 2 seconds",
+		},
+		{
+			attempt:     3,
+			expectedMin: 4 * time.Second,
+			expectedMax: 4 * time.Second,
+			description: "third retry: 4 seconds",
+		},
+		{
+			attempt:     4,
+			expectedMin: 8 * time.Second,
+			expectedMax: 8 * time.Second,
+			description: "fourth retry: 8 seconds",
+		},
+		{
+			attempt:     5,
+			expectedMin: 16 * time.Second,
+			expectedMax: 16 * time.Second,
+			description: "fifth retry: 16 seconds",
+		},
+		{
+			attempt:     6,
+			expectedMin: 30 * time.Second,
+			expectedMax: 30 * time.Second,
+			description: "sixth retry: capped at 30 seconds",
+		},
+		{
+			attempt:     10,
+			expectedMin: 30 * time.Second,
+			expectedMax: 30 * time.Second,
+			description: "large attempt: capped at 30 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			got := policy.Backoff(tt.attempt, 0)
+			if got < tt.expectedMin || got > tt.expectedMax {
+				t.Errorf("Backoff(%d) = %v, want between %v and %v",
+					tt.attempt, got, tt.expectedMin, tt.expectedMax)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_Backoff_CustomWait(t *testing.T) {
+	policy := &ExponentialBackoff{WaitMin: 100 * time.Millisecond, WaitMax: 500 * time.Millisecond}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 500 * time.Millisecond},
+		{10, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt_%d", tt.attempt), func(t *testing.T) {
+			if got := policy.Backoff(tt.attempt, 0); got != tt.expected {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFullJitterBackoff_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		random   float64
+		attempt  int
+		expected time.Duration
+	}{
+		{"lowest draw returns minimum", 0, 3, 1 * time.Second},
+		{"highest draw approaches exponential ceiling", 1, 3, 4 * time.Second},
+		{"midpoint draw", 0.5, 3, 2500 * time.Millisecond},
+		{"ceiling capped at maximum", 1, 10, 30 * time.Second},
+		{"first attempt has no spread", 0.7, 1, 1 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &FullJitterBackoff{
+				WaitMin: DefaultRetryWaitMin,
+				WaitMax: DefaultRetryWaitMax,
+				random:  func() float64 { return tt.random },
+			}
+
+			if got := policy.Backoff(tt.attempt, 0); got != tt.expected {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFullJitterBackoff_Backoff_Spread(t *testing.T) {
+	policy := &FullJitterBackoff{WaitMin: DefaultRetryWaitMin, WaitMax: DefaultRetryWaitMax}
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 50; i++ {
+		got := policy.Backoff(4, 0)
+		if got < 1*time.Second || got > 8*time.Second {
+			t.Fatalf("Backoff(4) = %v, want between 1s and 8s", got)
+		}
+		seen[got] = true
+	}
+
+	if len(seen) < 2 {
+		t.Error("expected jittered waits to differ between calls")
+	}
+}
+
+func TestDecorrelatedJitterBackoff_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		random   float64
+		previous time.Duration
+		expected time.Duration
+	}{
+		{"no previous wait starts at minimum", 0, 0, 1 * time.Second},
+		{"no previous wait grows up to three times minimum", 1, 0, 3 * time.Second},
+		{"grows from previous wait", 1, 4 * time.Second, 12 * time.Second},
+		{"midpoint draw", 0.5, 4 * time.Second, 6500 * time.Millisecond},
+		{"capped at maximum", 1, 20 * time.Second, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &DecorrelatedJitterBackoff{
+				WaitMin: DefaultRetryWaitMin,
+				WaitMax: DefaultRetryWaitMax,
+				random:  func() float64 { return tt.random },
+			}
+
+			if got := policy.Backoff(2, tt.previous); got != tt.expected {
+				t.Errorf("Backoff(2, %v) = %v, want %v", tt.previous, got, tt.expected)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{"EOF", &url.Error{Op: "Get", URL: "https://app.cachix.org", Err: io.EOF}, true},
+		{"unexpected EOF", fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF), true},
+		{"timeout", &url.Error{Op: "Get", URL: "https://app.cachix.org", Err: timeoutError{}}, true},
+		{"temporary DNS failure", &net.DNSError{Err: "server misbehaving", Name: "app.cachix.org", IsTemporary: true}, true},
+		{"DNS NXDOMAIN", &url.Error{Op: "Get", URL: "https://nope.invalid", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "nope.invalid", IsNotFound: true}}}, false},
+		{"unknown certificate authority", &url.Error{Op: "Get", URL: "https://app.cachix.org", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, false},
+		{"hostname mismatch", fmt.Errorf("tls: %w", x509.HostnameError{Host: "app.cachix.org", Certificate: &x509.Certificate{}}), false},
+		{"canceled", &url.Error{Op: "Get", URL: "https://app.cachix.org", Err: context.Canceled}, false},
+		{"unrelated error", errors.New("something else"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.expected {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryPolicies_ShouldRetry(t *testing.T) {
+	policies := map[string]RetryPolicy{
+		"exponential":         &ExponentialBackoff{WaitMin: time.Second, WaitMax: time.Minute},
+		"full jitter":         &FullJitterBackoff{WaitMin: time.Second, WaitMax: time.Minute},
+		"decorrelated jitter": &DecorrelatedJitterBackoff{WaitMin: time.Second, WaitMax: time.Minute},
+	}
+
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			if !policy.ShouldRetry(http.StatusServiceUnavailable, nil) {
+				t.Error("expected 503 to be retried")
+			}
+			if policy.ShouldRetry(http.StatusNotFound, nil) {
+				t.Error("expected 404 not to be retried")
+			}
+			if !policy.ShouldRetry(0, io.ErrUnexpectedEOF) {
+				t.Error("expected unexpected EOF to be retried")
+			}
+			if policy.ShouldRetry(0, x509.UnknownAuthorityError{}) {
+				t.Error("expected TLS verification failure not to be retried")
+			}
+		})
+	}
+}
+
+// countingPolicy is a deterministic RetryPolicy that records its calls.
+type countingPolicy struct {
+	ExponentialBackoff
+	backoffCalls []time.Duration
+}
+
+func (p *countingPolicy) Backoff(attempt int, previous time.Duration) time.Duration {
+	p.backoffCalls = append(p.backoffCalls, previous)
+	return p.ExponentialBackoff.Backoff(attempt, previous)
+}
+
+func TestCachixClient_doRequest_UsesRetryPolicy(t *testing.T) {
+	var attemptCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attemptCount, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := &countingPolicy{ExponentialBackoff: ExponentialBackoff{WaitMin: time.Millisecond, WaitMax: 4 * time.Millisecond}}
+	client := NewCachixClient(server.URL, "test-token", "1.0.0", WithRetryMax(3), WithRetryPolicy(policy))
+
+	if _, err := client.GetCache(context.Background(), "test-cache"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	expected := []time.Duration{0, time.Millisecond, 2 * time.Millisecond}
+	if len(policy.backoffCalls) != len(expected) {
+		t.Fatalf("expected %d backoff calls, got %d", len(expected), len(policy.backoffCalls))
+	}
+	for i, previous := range expected {
+		if policy.backoffCalls[i] != previous {
+			t.Errorf("backoff call %d: expected previous wait %v, got %v", i, previous, policy.backoffCalls[i])
+		}
+	}
+}
+
+func TestCachixClient_doRequest_NoRetryOnPermanentNetworkError(t *testing.T) {
+	var dialCount int32
+
+	client := NewCachixClient("https://app.cachix.org", "test-token", "1.0.0",
+		WithRetryWait(time.Millisecond, time.Millisecond),
+	)
+	client.httpClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			atomic.AddInt32(&dialCount, 1)
+			return nil, &net.DNSError{Err: "no such host", Name: "app.cachix.org", IsNotFound: true}
+		},
+	}
+
+	_, err := client.GetCache(context.Background(), "test-cache")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if dials := atomic.LoadInt32(&dialCount); dials != 1 {
+		t.Errorf("expected 1 attempt for NXDOMAIN, got %d", dials)
+	}
+}
+
+func TestCachixClient_doRequest_RetryOnConnectionReset(t *testing.T) {
+	var dialCount int32
+
+	client := NewCachixClient("https://app.cachix.org", "test-token", "1.0.0",
+		WithRetryMax(2),
+		WithRetryWait(time.Millisecond, time.Millisecond),
+	)
+	client.httpClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			atomic.AddInt32(&dialCount, 1)
+			return nil, &net.OpError{Op: "dial", Net: network, Err: os.NewSyscallError("connect", syscall.ECONNRESET)}
+		},
+	}
+
+	_, err := client.GetCache(context.Background(), "test-cache")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if dials := atomic.LoadInt32(&dialCount); dials != 3 {
+		t.Errorf("expected 3 attempts for connection reset, got %d", dials)
+	}
+}